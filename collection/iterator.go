@@ -32,3 +32,20 @@ func (iter *Iterator[V]) Peek() (V, bool) {
 	var zero V
 	return zero, false
 }
+
+// PeekN returns the item n places ahead without advancing, PeekN(1) is the same as Peek.
+func (iter *Iterator[V]) PeekN(n int) (V, bool) {
+	if n > 0 && iter.pos+n < iter.length {
+		return iter.slice[iter.pos+n], true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Backup steps back one item, so the next call to Next returns the same item again.
+func (iter *Iterator[V]) Backup() {
+	if iter.pos >= 0 {
+		iter.pos--
+	}
+}
@@ -38,3 +38,33 @@ func TestIterator_Peek(t *testing.T) {
 	assert.Equal(t, "one", one)
 	assert.Equal(t, -1, iter.pos)
 }
+
+func TestIterator_PeekN(t *testing.T) {
+	iter := NewIterator("one", "two", "three")
+	two, ok := iter.PeekN(2)
+	assert.True(t, ok)
+	assert.Equal(t, "two", two)
+	assert.Equal(t, -1, iter.pos)
+
+	one, ok := iter.PeekN(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", one)
+
+	_, ok = iter.PeekN(4)
+	assert.False(t, ok)
+	_, ok = iter.PeekN(0)
+	assert.False(t, ok)
+}
+
+func TestIterator_Backup(t *testing.T) {
+	iter := NewIterator("one", "two")
+	iter.Backup()
+	assert.Equal(t, -1, iter.pos)
+
+	_, _ = iter.Next()
+	_, _ = iter.Next()
+	iter.Backup()
+	two, ok := iter.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "two", two)
+}
@@ -1,9 +1,9 @@
+// Package ddl parses CREATE TABLE statements. The expressions of column defaults, generated
+// columns, options and CHECK constraints are parsed with the query package, so ddl depends on
+// query while query never imports ddl.
 package ddl
 
 import (
-	"strings"
-
-	"github.com/ryan-holcombe/sqlparser/lex"
 	"github.com/ryan-holcombe/sqlparser/parse"
 )
 
@@ -32,30 +32,24 @@ func addColumn(p *parse.Parser[CreateTable], column TableColumn, next parse.Stat
 	return next
 }
 
-func isKeyword(item lex.Item, keywords ...string) bool {
-	if item.Typ != lex.ItemKeyword {
-		return false
+func addConstraint(p *parse.Parser[CreateTable], constraint Constraint, next parse.StateFn[CreateTable]) parse.StateFn[CreateTable] {
+	if !parse.Validate(&constraint) {
+		return p.Errorf("invalid constraint [%s] found", constraint.Name)
 	}
-
-	for _, k := range keywords {
-		if strings.EqualFold(k, item.Val) {
-			return true
-		}
-	}
-
-	return false
+	p.Result.Constraints = append(p.Result.Constraints, constraint)
+	return next
 }
 
-func isIdentifier(item lex.Item, keywords ...string) bool {
-	if item.Typ != lex.ItemIdentifier {
-		return false
+// setPrimaryKey records the primary key of the table, which may only be declared once
+func setPrimaryKey(p *parse.Parser[CreateTable], key []KeyPart, next parse.StateFn[CreateTable]) parse.StateFn[CreateTable] {
+	if p.Result.PrimaryKey != nil {
+		return p.Errorf("table [%s] declares more than one primary key", p.Result.Name)
 	}
-
-	for _, k := range keywords {
-		if strings.EqualFold(k, item.Val) {
-			return true
+	for i := range key {
+		if !parse.Validate(&key[i]) {
+			return p.Errorf("invalid primary key column found")
 		}
 	}
-
-	return false
+	p.Result.PrimaryKey = key
+	return next
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ryan-holcombe/sqlparser/query"
 )
 
 func TestParse_CreateTable(t *testing.T) {
@@ -18,3 +20,291 @@ func TestParse_CreateTable(t *testing.T) {
 		_ = table
 	})
 }
+
+func TestParse_PrimaryKey(t *testing.T) {
+	t.Run("column primary key", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE users (user_id int PRIMARY KEY, username varchar(MAX) NOT NULL);`)
+		assert.NoError(t, err)
+		assert.Equal(t, []KeyPart{{Column: "user_id", Direction: query.SortAsc}}, table.PrimaryKey)
+		assert.Len(t, table.Columns, 2)
+	})
+
+	t.Run("spanner primary key", func(t *testing.T) {
+		input := strings.TrimSpace(`
+CREATE TABLE Albums (
+    SingerId INT64 NOT NULL,
+    AlbumId  INT64 NOT NULL,
+    Title    STRING(MAX)
+) PRIMARY KEY (SingerId, AlbumId DESC);`)
+		table, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []KeyPart{
+			{Column: "SingerId", Direction: query.SortAsc},
+			{Column: "AlbumId", Direction: query.SortDesc},
+		}, table.PrimaryKey)
+		assert.Len(t, table.Columns, 3)
+	})
+
+	t.Run("primary key constraint", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (a INT64, b INT64, PRIMARY KEY (a ASC, b))`)
+		assert.NoError(t, err)
+		assert.Equal(t, []KeyPart{{Column: "a", Direction: query.SortAsc}, {Column: "b", Direction: query.SortAsc}}, table.PrimaryKey)
+		assert.Len(t, table.Columns, 2)
+	})
+
+	t.Run("empty primary key", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (a INT64) PRIMARY KEY ()`)
+		assert.NoError(t, err)
+		assert.NotNil(t, table.PrimaryKey)
+		assert.Empty(t, table.PrimaryKey)
+	})
+
+	t.Run("no primary key", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (a INT64)`)
+		assert.NoError(t, err)
+		assert.Nil(t, table.PrimaryKey)
+	})
+
+	t.Run("two primary keys", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64 PRIMARY KEY, b INT64) PRIMARY KEY (b)`)
+		assert.Error(t, err)
+
+		_, err = Parse(`CREATE TABLE t (a INT64 PRIMARY KEY, b INT64 PRIMARY KEY)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unclosed primary key", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64) PRIMARY KEY (a`)
+		assert.Error(t, err)
+	})
+
+	t.Run("primary key within columns not followed by a separator", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, PRIMARY KEY (a) b INT64)`)
+		assert.EqualError(t, err, "expected ',' or ')' within the column list of [t], found [b] instead")
+	})
+}
+
+func TestParse_Interleave(t *testing.T) {
+	t.Run("on delete cascade", func(t *testing.T) {
+		input := strings.TrimSpace(`
+CREATE TABLE Albums (
+    SingerId INT64 NOT NULL,
+    AlbumId  INT64 NOT NULL
+) PRIMARY KEY (SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;`)
+		table, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, &Interleave{Parent: "Singers", OnDelete: OnDeleteCascade}, table.Interleave)
+		assert.Len(t, table.PrimaryKey, 2)
+	})
+
+	t.Run("on delete no action", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE Albums (SingerId INT64) PRIMARY KEY (SingerId), INTERLEAVE IN PARENT Singers ON DELETE NO ACTION`)
+		assert.NoError(t, err)
+		assert.Equal(t, &Interleave{Parent: "Singers", OnDelete: OnDeleteNoAction}, table.Interleave)
+	})
+
+	t.Run("without action", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE Albums (SingerId INT64) PRIMARY KEY (SingerId), INTERLEAVE IN PARENT Singers`)
+		assert.NoError(t, err)
+		assert.Equal(t, &Interleave{Parent: "Singers"}, table.Interleave)
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE Albums (SingerId INT64) PRIMARY KEY (SingerId), INTERLEAVE IN Singers`)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE Albums (SingerId INT64) PRIMARY KEY (SingerId), INTERLEAVE IN PARENT Singers ON DELETE RESTRICT`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_ColumnTypes(t *testing.T) {
+	t.Run("scalar and sized types", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64 NOT NULL, name STRING(MAX), digest BYTES(32), created TIMESTAMP) PRIMARY KEY (id)`)
+		assert.NoError(t, err)
+		assert.Equal(t, []TableColumn{
+			{Name: "id", Type: Type{Base: ColumnTypeInt64}, NotNull: true},
+			{Name: "name", Type: Type{Base: ColumnTypeString, Size: "MAX"}},
+			{Name: "digest", Type: Type{Base: ColumnTypeBytes, Size: "32"}},
+			{Name: "created", Type: Type{Base: ColumnTypeTimestamp}},
+		}, table.Columns)
+	})
+
+	t.Run("arrays and structs", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64, tags ARRAY<STRING(MAX)> NOT NULL, points ARRAY<STRUCT<x FLOAT64, y FLOAT64>>) PRIMARY KEY (id)`)
+		assert.NoError(t, err)
+		assert.Equal(t, TableColumn{
+			Name:    "tags",
+			Type:    Type{Base: ColumnTypeArray, Elem: &Type{Base: ColumnTypeString, Size: "MAX"}},
+			NotNull: true,
+		}, table.Columns[1])
+		assert.Equal(t, Type{Base: ColumnTypeArray, Elem: &Type{Base: ColumnTypeStruct, Fields: []Field{
+			{Name: "x", Type: Type{Base: ColumnTypeFloat64}},
+			{Name: "y", Type: Type{Base: ColumnTypeFloat64}},
+		}}}, table.Columns[2].Type)
+		for _, column := range table.Columns {
+			assert.True(t, column.Type.Known(), column.Name)
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id int)`)
+		assert.NoError(t, err)
+		assert.False(t, table.Columns[0].Type.Known())
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (id, name STRING(MAX))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unclosed array", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (tags ARRAY<STRING(MAX) NOT NULL)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_ColumnExpressions(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64, created TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP()), score INT64 DEFAULT (0)) PRIMARY KEY (id)`)
+		assert.NoError(t, err)
+		assert.Equal(t, query.FuncCall{Name: "CURRENT_TIMESTAMP"}, table.Columns[1].Default)
+		assert.True(t, table.Columns[1].NotNull)
+		assert.Equal(t, query.Literal{Kind: query.LiteralNumber, Val: "0"}, table.Columns[2].Default)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (a INT64, b INT64, total INT64 AS (a + b) STORED, half FLOAT64 AS (a / 2)) PRIMARY KEY (a)`)
+		assert.NoError(t, err)
+		assert.Equal(t, query.Binary{Op: "+", Left: query.ColumnRef{Column: "a"}, Right: query.ColumnRef{Column: "b"}}, table.Columns[2].Generated)
+		assert.True(t, table.Columns[2].Stored)
+		assert.NotNil(t, table.Columns[3].Generated)
+		assert.False(t, table.Columns[3].Stored)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64, updated TIMESTAMP OPTIONS (allow_commit_timestamp = true)) PRIMARY KEY (id)`)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]query.Literal{
+			"allow_commit_timestamp": {Kind: query.LiteralBool, Val: "TRUE"},
+		}, table.Columns[1].Options)
+		assert.Nil(t, table.Columns[0].Options)
+	})
+
+	t.Run("negative option value", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64 OPTIONS (retention = -1, ratio = -0.5))`)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]query.Literal{
+			"retention": {Kind: query.LiteralNumber, Val: "-1"},
+			"ratio":     {Kind: query.LiteralNumber, Val: "-0.5"},
+		}, table.Columns[0].Options)
+
+		_, err = Parse(`CREATE TABLE t (id INT64 OPTIONS (retention = -'a'))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("default without parentheses", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (score INT64 DEFAULT 0)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid default expression", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (score INT64 DEFAULT (1 +))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("default and generated", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, b INT64 DEFAULT (1) AS (a + 1))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("option value is not a literal", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (updated TIMESTAMP OPTIONS (allow_commit_timestamp = a + 1))`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Constraints(t *testing.T) {
+	t.Run("foreign keys", func(t *testing.T) {
+		input := strings.TrimSpace(`
+CREATE TABLE Albums (
+    SingerId INT64 NOT NULL,
+    LabelId  INT64,
+    AlbumId  INT64 NOT NULL,
+    CONSTRAINT fk_singer FOREIGN KEY (SingerId) REFERENCES Singers (SingerId) ON DELETE CASCADE,
+    FOREIGN KEY (LabelId, SingerId) REFERENCES Labels (LabelId, OwnerId)
+) PRIMARY KEY (SingerId, AlbumId);`)
+		table, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, table.Columns, 3)
+		assert.Equal(t, []Constraint{
+			{
+				Name:       "fk_singer",
+				Kind:       ConstraintForeignKey,
+				Columns:    []string{"SingerId"},
+				RefTable:   "Singers",
+				RefColumns: []string{"SingerId"},
+				OnDelete:   OnDeleteCascade,
+			},
+			{
+				Kind:       ConstraintForeignKey,
+				Columns:    []string{"LabelId", "SingerId"},
+				RefTable:   "Labels",
+				RefColumns: []string{"LabelId", "OwnerId"},
+			},
+		}, table.Constraints)
+		assert.Len(t, table.PrimaryKey, 2)
+	})
+
+	t.Run("checks", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (id INT64, x INT64, CONSTRAINT ck CHECK (x > 0), CHECK (x < 100), PRIMARY KEY (id))`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Constraint{
+			{
+				Name:  "ck",
+				Kind:  ConstraintCheck,
+				Check: query.Binary{Op: ">", Left: query.ColumnRef{Column: "x"}, Right: query.Literal{Kind: query.LiteralNumber, Val: "0"}},
+			},
+			{
+				Kind:  ConstraintCheck,
+				Check: query.Binary{Op: "<", Left: query.ColumnRef{Column: "x"}, Right: query.Literal{Kind: query.LiteralNumber, Val: "100"}},
+			},
+		}, table.Constraints)
+		assert.Equal(t, []KeyPart{{Column: "id", Direction: query.SortAsc}}, table.PrimaryKey)
+	})
+
+	t.Run("columns named like constraints", func(t *testing.T) {
+		table, err := Parse(`CREATE TABLE t (check INT64, constraint STRING(MAX), foreign BOOL)`)
+		assert.NoError(t, err)
+		assert.Len(t, table.Columns, 3)
+		assert.Empty(t, table.Constraints)
+	})
+
+	t.Run("mismatched foreign key columns", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, b INT64, FOREIGN KEY (a, b) REFERENCES o (a))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("foreign without key", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, CONSTRAINT fk FOREIGN BOGUS (a) REFERENCES p (b))`)
+		assert.EqualError(t, err, "expected KEY after FOREIGN, found [BOGUS] instead")
+	})
+
+	t.Run("missing references", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, FOREIGN KEY (a) o (a))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, FOREIGN KEY (a) REFERENCES o (a) ON DELETE SET NULL)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("check without expression", func(t *testing.T) {
+		_, err := Parse(`CREATE TABLE t (a INT64, CONSTRAINT ck CHECK ())`)
+		assert.Error(t, err)
+	})
+}
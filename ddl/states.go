@@ -5,6 +5,8 @@ import (
 
 	"github.com/ryan-holcombe/sqlparser/lex"
 	"github.com/ryan-holcombe/sqlparser/parse"
+	"github.com/ryan-holcombe/sqlparser/query"
+	"github.com/ryan-holcombe/sqlparser/types"
 )
 
 func createTable(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
@@ -14,9 +16,9 @@ func createTable(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
 		return addComment(p, next.Val, createTable)
 	case lex.ItemKeyword:
 		switch {
-		case isKeyword(next, "create"):
+		case lex.IsKeyword(next, "create"):
 			return createTable
-		case isKeyword(next, "table"):
+		case lex.IsKeyword(next, "table"):
 			// found CREATE TABLE
 			return tableName
 		default:
@@ -47,44 +49,363 @@ func tableColumns(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
 	for {
 		next := p.MustNext()
 		switch {
-		case isKeyword(next, "not"):
+		case lex.IsKeyword(next, "not"):
 			peek := p.MustPeek()
-			if isKeyword(peek, "null") {
+			if lex.IsKeyword(peek, "null") {
 				column.NotNull = true
 				p.Skip()
 			} else {
 				return p.Errorf("unsupported next type [%v] found while parsing the not null for [%s]", peek.Typ, column.Name)
 			}
-		case isKeyword(next, "primary"):
+		case lex.IsKeyword(next, "default"):
+			expr, ok := parenExpr(p)
+			if !ok {
+				return p.Errorf("invalid default found for column [%s]", column.Name)
+			}
+			column.Default = expr
+		case lex.IsKeyword(next, "as"):
+			expr, ok := parenExpr(p)
+			if !ok {
+				return p.Errorf("invalid generated column [%s] found", column.Name)
+			}
+			column.Generated = expr
+			if lex.IsIdentifier(p.MustPeek(), "stored") {
+				p.Skip()
+				column.Stored = true
+			}
+		case lex.IsIdentifier(next, "options") && column.Type.Base != "":
+			options, ok := columnOptions(p)
+			if !ok {
+				return p.Errorf("invalid options found for column [%s]", column.Name)
+			}
+			column.Options = options
+		case lex.IsKeyword(next, "primary") && column.Name == "":
+			// a PRIMARY KEY (...) constraint within the column list
+			if !lex.IsIdentifier(p.MustNext(), "key") {
+				return p.Errorf("expected KEY after PRIMARY within [%s]", p.Result.Name)
+			}
+			key := keyParts(p)
+			if p.HasError() {
+				return nil
+			}
+			return setPrimaryKey(p, key, columnListNext)
+		case column.Name == "" && isConstraintStart(p, next):
+			constraint, ok := tableConstraint(p, next)
+			if !ok {
+				return nil
+			}
+			return addConstraint(p, constraint, columnListNext)
+		case lex.IsKeyword(next, "primary"):
 			peek := p.MustPeek()
-			if isIdentifier(peek, "key") {
-				//column.PrimaryKey = true
+			if lex.IsIdentifier(peek, "key") {
 				p.Skip()
+				if setPrimaryKey(p, []KeyPart{{Column: column.Name, Direction: query.SortAsc}}, tableColumns); p.HasError() {
+					return nil
+				}
 			} else {
 				return p.Errorf("unsupported next type [%v] found while parsing the primary key for [%s]", peek.Typ, column.Name)
 			}
 		case next.Typ == lex.ItemIdentifier && column.Name == "":
 			column.Name = next.Val
-		case next.Typ == lex.ItemIdentifier:
-			column.BaseType = strings.ToUpper(next.Val)
-		case next.Typ == lex.ItemLeftParen:
-			next := p.MustNext()
-			peek := p.MustPeek()
-			if next.Typ == lex.ItemIdentifier && peek.Typ == lex.ItemRightParen {
-				column.TypeSize = strings.ToUpper(next.Val)
-				p.Skip()
-			} else {
-				return p.Errorf("unsupported next type [%v] found while parsing the type size for [%s]", next.Typ, column.Name)
+		case (next.Typ == lex.ItemIdentifier || lex.IsKeyword(next, "array", "struct")) && column.Type.Base == "":
+			p.Backup()
+			typ, ok := types.Parse(p)
+			if !ok {
+				return p.Errorf("invalid type found for column [%s]", column.Name)
 			}
+			column.Type = typ
 		case next.Typ == lex.ItemComma:
 			// add the column to the table and look for another
 			return addColumn(p, column, tableColumns)
 		case next.Typ == lex.ItemRightParen:
-			// add the column to the table and return
-			return addColumn(p, column, nil)
+			// add the column to the table and look for the clauses following the column list
+			return addColumn(p, column, tableClauses)
 		default:
 			return p.Errorf("unsupported next type [%v] found within [%s]", next.Typ, "tableColumns")
 		}
 
 	}
 }
+
+// tableClauses parses the clauses following the column list, e.g. PRIMARY KEY (a, b DESC)
+func tableClauses(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
+	next := p.MustNext()
+	switch {
+	case lex.IsKeyword(next, "primary"):
+		if !lex.IsIdentifier(p.MustNext(), "key") {
+			return p.Errorf("expected KEY after PRIMARY within [%s]", p.Result.Name)
+		}
+		key := keyParts(p)
+		if p.HasError() {
+			return nil
+		}
+		return setPrimaryKey(p, key, tableClauses)
+	case lex.IsIdentifier(next, "interleave"):
+		return interleave
+	case next.Typ == lex.ItemComma:
+		return tableClauses
+	case next.Typ == lex.ItemStatementEnd, next.Typ == lex.ItemEOF:
+		return nil
+	default:
+		return p.Errorf("unsupported next type [%v] found within [%s]", next.Typ, "tableClauses")
+	}
+}
+
+// interleave parses the parent and ON DELETE action of INTERLEAVE IN PARENT
+func interleave(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
+	if p.Result.Interleave != nil {
+		return p.Errorf("table [%s] is interleaved more than once", p.Result.Name)
+	}
+	if in, parent := p.MustNext(), p.MustNext(); !lex.IsKeyword(in, "in") || !lex.IsIdentifier(parent, "parent") {
+		return p.Errorf("expected IN PARENT after INTERLEAVE, found [%s %s] instead", in.Val, parent.Val)
+	}
+
+	var interleave Interleave
+	if next := p.MustNext(); next.Typ == lex.ItemIdentifier {
+		interleave.Parent = next.Val
+	} else {
+		return p.Errorf("expected parent table name, found [%s] instead", next.Val)
+	}
+
+	action, ok := onDelete(p)
+	if !ok {
+		return nil
+	}
+	interleave.OnDelete = action
+
+	p.Result.Interleave = &interleave
+	return tableClauses
+}
+
+// onDelete parses an `ON DELETE CASCADE` or `ON DELETE NO ACTION` when one follows, the action is
+// empty when none does
+func onDelete(p *parse.Parser[CreateTable]) (OnDelete, bool) {
+	if !lex.IsKeyword(p.MustPeek(), "on") {
+		return "", true
+	}
+	p.Skip()
+	if next := p.MustNext(); !lex.IsIdentifier(next, "delete") {
+		p.Errorf("expected DELETE after ON, found [%s] instead", next.Val)
+		return "", false
+	}
+
+	switch next := p.MustNext(); {
+	case lex.IsIdentifier(next, "cascade"):
+		return OnDeleteCascade, true
+	case lex.IsKeyword(next, "no") && lex.IsIdentifier(p.MustPeek(), "action"):
+		p.Skip()
+		return OnDeleteNoAction, true
+	default:
+		p.Errorf("expected CASCADE or NO ACTION after ON DELETE, found [%s] instead", next.Val)
+		return "", false
+	}
+}
+
+// columnListNext continues after a PRIMARY KEY or constraint within the column list, with the next
+// column or the clauses that follow the list
+func columnListNext(p *parse.Parser[CreateTable]) parse.StateFn[CreateTable] {
+	switch next := p.MustNext(); next.Typ {
+	case lex.ItemComma:
+		return tableColumns
+	case lex.ItemRightParen:
+		return tableClauses
+	default:
+		return p.Errorf("expected ',' or ')' within the column list of [%s], found [%s] instead", p.Result.Name, next.Val)
+	}
+}
+
+// isConstraintStart reports whether next begins a table constraint rather than a column, which
+// may itself be named e.g. check. It only peeks at the items following next, reading none of them.
+func isConstraintStart(p *parse.Parser[CreateTable], next lex.Item) bool {
+	switch {
+	case lex.IsIdentifier(next, "foreign"):
+		return lex.IsIdentifier(p.MustPeek(), "key")
+	case lex.IsIdentifier(next, "check"):
+		return p.MustPeek().Typ == lex.ItemLeftParen
+	case lex.IsIdentifier(next, "constraint"):
+		return p.MustPeek().Typ == lex.ItemIdentifier && lex.IsIdentifier(p.MustPeekN(2), "foreign", "check")
+	default:
+		return false
+	}
+}
+
+// tableConstraint parses a FOREIGN KEY or CHECK constraint, optionally named with CONSTRAINT
+func tableConstraint(p *parse.Parser[CreateTable], next lex.Item) (Constraint, bool) {
+	var constraint Constraint
+	if lex.IsIdentifier(next, "constraint") {
+		constraint.Name = p.MustNext().Val
+		next = p.MustNext()
+	}
+
+	switch {
+	case lex.IsIdentifier(next, "foreign"):
+		constraint.Kind = ConstraintForeignKey
+		if next := p.MustNext(); !lex.IsIdentifier(next, "key") {
+			p.Errorf("expected KEY after FOREIGN, found [%s] instead", next.Val)
+			return constraint, false
+		}
+		if constraint.Columns = columnNames(p); constraint.Columns == nil {
+			return constraint, false
+		}
+		if next := p.MustNext(); !lex.IsIdentifier(next, "references") {
+			p.Errorf("expected REFERENCES within foreign key, found [%s] instead", next.Val)
+			return constraint, false
+		}
+		if next := p.MustNext(); next.Typ == lex.ItemIdentifier {
+			constraint.RefTable = next.Val
+		} else {
+			p.Errorf("expected referenced table name, found [%s] instead", next.Val)
+			return constraint, false
+		}
+		if constraint.RefColumns = columnNames(p); constraint.RefColumns == nil {
+			return constraint, false
+		}
+		action, ok := onDelete(p)
+		constraint.OnDelete = action
+		return constraint, ok
+	case lex.IsIdentifier(next, "check"):
+		constraint.Kind = ConstraintCheck
+		expr, ok := parenExpr(p)
+		constraint.Check = expr
+		return constraint, ok
+	default:
+		p.Errorf("expected FOREIGN KEY or CHECK within constraint [%s], found [%s] instead", constraint.Name, next.Val)
+		return constraint, false
+	}
+}
+
+// columnNames parses a parenthesized, comma separated list of column names
+func columnNames(p *parse.Parser[CreateTable]) []string {
+	if next := p.MustNext(); next.Typ != lex.ItemLeftParen {
+		p.Errorf("expected left parenthesis before column names, found [%s] instead", next.Val)
+		return nil
+	}
+
+	var names []string
+	for {
+		next := p.MustNext()
+		if next.Typ != lex.ItemIdentifier {
+			p.Errorf("expected column name, found [%s] instead", next.Val)
+			return nil
+		}
+		names = append(names, next.Val)
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return names
+		default:
+			p.Errorf("expected ',' or ')' within column names, found [%s] instead", next.Val)
+			return nil
+		}
+	}
+}
+
+// parenExpr parses a parenthesized expression, such as the default value of a column
+func parenExpr(p *parse.Parser[CreateTable]) (query.Expr, bool) {
+	if next := p.MustNext(); next.Typ != lex.ItemLeftParen {
+		p.Errorf("expected left parenthesis before expression, found [%s] instead", next.Val)
+		return nil, false
+	}
+	expr, ok := query.ParseExpr(p)
+	if !ok {
+		return nil, false
+	}
+	if next := p.MustNext(); next.Typ != lex.ItemRightParen {
+		p.Errorf("expected right parenthesis after expression, found [%s] instead", next.Val)
+		return nil, false
+	}
+	return expr, true
+}
+
+// columnOptions parses the parenthesized `name = value` pairs of OPTIONS, each value must be a literal
+func columnOptions(p *parse.Parser[CreateTable]) (map[string]query.Literal, bool) {
+	if next := p.MustNext(); next.Typ != lex.ItemLeftParen {
+		p.Errorf("expected left parenthesis after OPTIONS, found [%s] instead", next.Val)
+		return nil, false
+	}
+
+	options := map[string]query.Literal{}
+	for {
+		name := p.MustNext()
+		if name.Typ != lex.ItemIdentifier {
+			p.Errorf("expected option name, found [%s] instead", name.Val)
+			return nil, false
+		}
+		if next := p.MustNext(); next.Typ != lex.ItemOperator || next.Val != "=" {
+			p.Errorf("expected '=' after option [%s], found [%s] instead", name.Val, next.Val)
+			return nil, false
+		}
+		expr, ok := query.ParseExpr(p)
+		if !ok {
+			return nil, false
+		}
+		value, ok := optionValue(expr)
+		if !ok {
+			p.Errorf("expected a literal value for option [%s]", name.Val)
+			return nil, false
+		}
+		options[strings.ToLower(name.Val)] = value
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return options, true
+		default:
+			p.Errorf("expected ',' or ')' within OPTIONS, found [%s] instead", next.Val)
+			return nil, false
+		}
+	}
+}
+
+// optionValue returns the literal value of an option, a negative number such as -1 is parsed as
+// unary minus applied to a number and is folded back into a single literal
+func optionValue(expr query.Expr) (query.Literal, bool) {
+	if unary, ok := expr.(query.Unary); ok && unary.Op == "-" {
+		if lit, ok := unary.Expr.(query.Literal); ok && lit.Kind == query.LiteralNumber {
+			return query.Literal{Kind: query.LiteralNumber, Val: "-" + lit.Val}, true
+		}
+		return query.Literal{}, false
+	}
+	value, ok := expr.(query.Literal)
+	return value, ok
+}
+
+// keyParts parses the parenthesized columns of a primary key, each with an optional ASC or DESC
+func keyParts(p *parse.Parser[CreateTable]) []KeyPart {
+	if next := p.MustNext(); next.Typ != lex.ItemLeftParen {
+		p.Errorf("expected left parenthesis after PRIMARY KEY, found [%s] instead", next.Val)
+		return nil
+	}
+	if p.MustPeek().Typ == lex.ItemRightParen { // PRIMARY KEY ()
+		p.Skip()
+		return []KeyPart{}
+	}
+
+	var key []KeyPart
+	for {
+		next := p.MustNext()
+		if next.Typ != lex.ItemIdentifier {
+			p.Errorf("expected column name within primary key, found [%s] instead", next.Val)
+			return nil
+		}
+		part := KeyPart{Column: next.Val, Direction: query.SortAsc}
+		if dir := p.MustPeek(); lex.IsKeyword(dir, "asc", "desc") {
+			p.Skip()
+			part.Direction = query.SortDirection(strings.ToUpper(dir.Val))
+		}
+		key = append(key, part)
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return key
+		default:
+			p.Errorf("expected ',' or ')' within primary key, found [%s] instead", next.Val)
+			return nil
+		}
+	}
+}
@@ -1,33 +1,121 @@
 package ddl
 
-type ColumnType string
+import (
+	"github.com/ryan-holcombe/sqlparser/query"
+	"github.com/ryan-holcombe/sqlparser/types"
+)
 
-func (s ColumnType) String() string {
-	return string(s)
-}
+// ColumnType, Type and Field are shared with the query package, see package types
+type (
+	ColumnType = types.ColumnType
+	Type       = types.Type
+	Field      = types.Field
+)
 
 const (
-	ColumnTypeBool      ColumnType = "BOOL"
-	ColumnTypeInt64     ColumnType = "INT64"
-	ColumnTypeFloat64   ColumnType = "FLOAT64"
-	ColumnTypeNumeric   ColumnType = "NUMERIC"
-	ColumnTypeString    ColumnType = "STRING"
-	ColumnTypeBytes     ColumnType = "BYTES"
-	ColumnTypeDate      ColumnType = "DATE"
-	ColumnTypeTimestamp ColumnType = "TIMESTAMP"
-	ColumnTypeJSON      ColumnType = "JSON"
+	ColumnTypeBool      = types.ColumnTypeBool
+	ColumnTypeInt64     = types.ColumnTypeInt64
+	ColumnTypeFloat64   = types.ColumnTypeFloat64
+	ColumnTypeNumeric   = types.ColumnTypeNumeric
+	ColumnTypeString    = types.ColumnTypeString
+	ColumnTypeBytes     = types.ColumnTypeBytes
+	ColumnTypeDate      = types.ColumnTypeDate
+	ColumnTypeTimestamp = types.ColumnTypeTimestamp
+	ColumnTypeJSON      = types.ColumnTypeJSON
+	ColumnTypeArray     = types.ColumnTypeArray
+	ColumnTypeStruct    = types.ColumnTypeStruct
 )
 
 type CreateTable struct {
-	Name     string
-	Comments []string
-	Columns  []TableColumn
+	Name        string
+	Comments    []string
+	Columns     []TableColumn
+	PrimaryKey  []KeyPart // nil when the table declares no primary key, empty for PRIMARY KEY ()
+	Interleave  *Interleave
+	Constraints []Constraint
+}
+
+type ConstraintKind string
+
+func (k ConstraintKind) String() string {
+	return string(k)
+}
+
+const (
+	ConstraintForeignKey ConstraintKind = "FOREIGN KEY"
+	ConstraintCheck      ConstraintKind = "CHECK"
+)
+
+// Constraint is a FOREIGN KEY or CHECK constraint within the column list, Name is empty for an
+// unnamed constraint. A foreign key sets Columns, RefTable, RefColumns and OnDelete, a check sets
+// Check.
+type Constraint struct {
+	Name       string
+	Kind       ConstraintKind
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   OnDelete
+	Check      query.Expr
 }
 
+func (c Constraint) Valid() bool {
+	switch c.Kind {
+	case ConstraintForeignKey:
+		return len(c.Columns) > 0 && c.RefTable != "" && len(c.RefColumns) == len(c.Columns) && c.Check == nil
+	case ConstraintCheck:
+		return c.Check != nil && len(c.Columns) == 0 && c.RefTable == "" && len(c.RefColumns) == 0 && c.OnDelete == ""
+	default:
+		return false
+	}
+}
+
+type OnDelete string
+
+func (o OnDelete) String() string {
+	return string(o)
+}
+
+const (
+	OnDeleteCascade  OnDelete = "CASCADE"
+	OnDeleteNoAction OnDelete = "NO ACTION"
+)
+
+// Interleave is the Spanner `INTERLEAVE IN PARENT table [ON DELETE action]` clause, which stores
+// the rows of a table with the row of its parent. OnDelete is empty when no action is given,
+// which Spanner treats as NO ACTION.
+type Interleave struct {
+	Parent   string
+	OnDelete OnDelete
+}
+
+// KeyPart is a column of a primary key, Direction is ASC unless DESC is given
+type KeyPart struct {
+	Column    string
+	Direction query.SortDirection
+}
+
+func (k KeyPart) Valid() bool {
+	return k.Column != "" && (k.Direction == query.SortAsc || k.Direction == query.SortDesc)
+}
+
+// TableColumn is a column of a table. Type may be any well formed type, as in the query package,
+// Type.Known reports whether it is built from the ColumnType constants. Default is the expression of `DEFAULT (expr)` and Generated
+// that of a generated column, `AS (expr) [STORED]`. Options holds the values of
+// `OPTIONS (name = value, ...)` by lower case name, e.g. allow_commit_timestamp.
 type TableColumn struct {
-	Name     string
-	BaseType string // base type: BOOL, INT64, FLOAT64, NUMERIC, STRING, BYTES, DATE, TIMESTAMP, JSON
-	TypeSize string // the number in parentheses: STRING(10), STRING(MAX)
-	Array    bool
-	NotNull  bool
+	Name      string
+	Type      Type // e.g. INT64, STRING(MAX), ARRAY<STRUCT<a INT64>>
+	NotNull   bool
+	Default   query.Expr
+	Generated query.Expr
+	Stored    bool
+	Options   map[string]query.Literal
+}
+
+func (c TableColumn) Valid() bool {
+	if c.Default != nil && c.Generated != nil {
+		return false
+	}
+	return c.Name != "" && c.Type.Valid() && (c.Generated != nil || !c.Stored)
 }
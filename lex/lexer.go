@@ -10,7 +10,7 @@ import (
 )
 
 type Item struct {
-	Typ  itemType // this Item's type
+	Typ  ItemType // this Item's type
 	Val  string   // the raw value of the Item
 	Line int      // the line number within the input string
 	pos  int      // the starting position, in bytes
@@ -29,40 +29,134 @@ func (i Item) String() string {
 	return fmt.Sprintf("%q", i.Val)
 }
 
-type itemType string
+// IsKeyword reports whether item is a keyword matching one of keywords, ignoring case
+func IsKeyword(item Item, keywords ...string) bool {
+	return item.Typ == ItemKeyword && matchesAny(item.Val, keywords)
+}
+
+// IsIdentifier reports whether item is an unquoted identifier matching one of identifiers,
+// ignoring case. It is used for the words with a meaning in some positions that are not reserved.
+func IsIdentifier(item Item, identifiers ...string) bool {
+	return item.Typ == ItemIdentifier && matchesAny(item.Val, identifiers)
+}
+
+func matchesAny(val string, words []string) bool {
+	for _, w := range words {
+		if strings.EqualFold(w, val) {
+			return true
+		}
+	}
+	return false
+}
+
+type ItemType string
 
-// itemType identifies the type of lex items
+// ItemType identifies the type of lex items
 const (
-	ItemError                itemType = "ItemError"                // error occurred; value is text of error
-	ItemEOF                  itemType = "ItemEOF"                  // end of file
-	ItemSingleLineComment    itemType = "ItemSingleLineComment"    // A comment like --
-	ItemMultiLineComment     itemType = "ItemMultiLineComment"     // A multiline comment like /* ... */
-	ItemKeyword              itemType = "ItemKeyword"              // SQL language keyword like SELECT, INSERT, etc.
-	ItemIdentifier           itemType = "ItemIdentifier"           // alphanumeric non-keyword identifier
-	ItemBacktickedIdentifier itemType = "ItemBacktickedIdentifier" // '`users`'
-	ItemOperator             itemType = "ItemOperator"             // operators like '=', '<>', etc.
-	ItemLeftParen            itemType = "ItemLeftParen"            // '('
-	ItemRightParen           itemType = "ItemRightParen"           // ')'
-	ItemComma                itemType = "ItemComma"                // ','
-	ItemDot                  itemType = "ItemDot"                  // '.'
-	ItemStatementEnd         itemType = "ItemStatementEnd"         // ';'
-	ItemNumber               itemType = "ItemNumber"               // simple number
-	ItemString               itemType = "ItemString"               // quoted string (includes quotes)
+	ItemError                ItemType = "ItemError"                // error occurred; value is text of error
+	ItemEOF                  ItemType = "ItemEOF"                  // end of file
+	ItemSingleLineComment    ItemType = "ItemSingleLineComment"    // A comment like --
+	ItemMultiLineComment     ItemType = "ItemMultiLineComment"     // A multiline comment like /* ... */
+	ItemKeyword              ItemType = "ItemKeyword"              // SQL language keyword like SELECT, INSERT, etc.
+	ItemIdentifier           ItemType = "ItemIdentifier"           // alphanumeric non-keyword identifier
+	ItemBacktickedIdentifier ItemType = "ItemBacktickedIdentifier" // '`users`'
+	ItemOperator             ItemType = "ItemOperator"             // operators like '=', '<>', etc.
+	ItemLeftParen            ItemType = "ItemLeftParen"            // '('
+	ItemRightParen           ItemType = "ItemRightParen"           // ')'
+	ItemLeftBracket          ItemType = "ItemLeftBracket"          // '['
+	ItemRightBracket         ItemType = "ItemRightBracket"         // ']'
+	ItemComma                ItemType = "ItemComma"                // ','
+	ItemDot                  ItemType = "ItemDot"                  // '.'
+	ItemStatementEnd         ItemType = "ItemStatementEnd"         // ';'
+	ItemNumber               ItemType = "ItemNumber"               // simple number
+	ItemString               ItemType = "ItemString"               // quoted string (includes quotes)
 )
 
 const (
-	KeywordFrom = "from"
+	KeywordWith      = "with"
+	KeywordRecursive = "recursive"
+	KeywordSelect    = "select"
+	KeywordFrom      = "from"
+	KeywordInto      = "into"
+	KeywordDefault   = "default"
+	KeywordSet       = "set"
+	KeywordMerge     = "merge"
+	KeywordWhen      = "when"
+	KeywordThen      = "then"
 
 	KeywordAs = "as"
 
-	KeywordJoin  = "join"
-	KeywordLeft  = "left"
-	KeywordRight = "right"
-	KeywordInner = "inner"
-	KeywordOuter = "outer"
-	KeywordFull  = "full"
-
-	KeywordWhere = "where"
+	KeywordJoin    = "join"
+	KeywordLeft    = "left"
+	KeywordRight   = "right"
+	KeywordInner   = "inner"
+	KeywordOuter   = "outer"
+	KeywordFull    = "full"
+	KeywordCross   = "cross"
+	KeywordNatural = "natural"
+	KeywordOn      = "on"
+	KeywordUsing   = "using"
+
+	KeywordWhere    = "where"
+	KeywordGroup    = "group"
+	KeywordBy       = "by"
+	KeywordRollup   = "rollup"
+	KeywordCube     = "cube"
+	KeywordGrouping = "grouping"
+	KeywordHaving   = "having"
+	KeywordOrder    = "order"
+	KeywordAsc      = "asc"
+	KeywordDesc     = "desc"
+	KeywordNulls    = "nulls"
+	KeywordLimit    = "limit"
+
+	KeywordWindow    = "window"
+	KeywordOver      = "over"
+	KeywordPartition = "partition"
+	KeywordRows      = "rows"
+	KeywordRange     = "range"
+	KeywordGroups    = "groups"
+	KeywordUnbounded = "unbounded"
+	KeywordCurrent   = "current"
+	KeywordPreceding = "preceding"
+	KeywordFollowing = "following"
+
+	KeywordUnion     = "union"
+	KeywordIntersect = "intersect"
+	KeywordExcept    = "except"
+	KeywordAll       = "all"
+	KeywordDistinct  = "distinct"
+
+	KeywordAnd     = "and"
+	KeywordOr      = "or"
+	KeywordNot     = "not"
+	KeywordIs      = "is"
+	KeywordNull    = "null"
+	KeywordTrue    = "true"
+	KeywordFalse   = "false"
+	KeywordBetween = "between"
+	KeywordLike    = "like"
+	KeywordIn      = "in"
+	KeywordExists  = "exists"
+
+	KeywordIf      = "if"
+	KeywordIgnore  = "ignore"
+	KeywordRespect = "respect"
+
+	KeywordCase     = "case"
+	KeywordElse     = "else"
+	KeywordEnd      = "end"
+	KeywordCast     = "cast"
+	KeywordExtract  = "extract"
+	KeywordInterval = "interval"
+	KeywordAt       = "at"
+	KeywordTo       = "to"
+	KeywordArray    = "array"
+	KeywordStruct   = "struct"
+	KeywordUnnest   = "unnest"
+
+	KeywordFor         = "for"
+	KeywordTablesample = "tablesample"
 )
 
 // keywords is a list of reserved SQL keywords
@@ -173,6 +267,9 @@ const (
 	multiLineCommentEnd    = "*/"
 )
 
+// multiCharOperators are the operators made up of more than a single rune
+var multiCharOperators = []string{"<>", "<=", ">=", "!=", "||", "<<", ">>", "=>"}
+
 type Lexer struct {
 	input string    // the string being scanned
 	start int       // start position of this Item
@@ -224,7 +321,7 @@ func (l *Lexer) run() {
 	close(l.items) // no more tokens will be delivered
 }
 
-func (l *Lexer) emit(t itemType) {
+func (l *Lexer) emit(t ItemType) {
 	l.items <- Item{
 		Typ:  t,
 		Val:  l.input[l.start:l.pos],
@@ -255,6 +352,10 @@ func (l *Lexer) ignore() {
 
 // backup steps back one rune. Can be called only once per call of next.
 func (l *Lexer) backup() {
+	// nothing was consumed when the last call to next reached eof
+	if l.width == 0 {
+		return
+	}
 	if l.pos > 0 {
 		r, w := utf8.DecodeLastRuneInString(l.input[:l.pos])
 		l.pos -= w
@@ -316,7 +417,7 @@ func isAlphaNumeric(r rune) bool {
 
 // isOperator reports whether r is an operator.
 func isOperator(r rune) bool {
-	return r == '+' || r == '-' || r == '*' || r == '/' || r == '=' || r == '>' || r == '<' || r == '~' || r == '|' || r == '^' || r == '&' || r == '%'
+	return r == '+' || r == '-' || r == '*' || r == '/' || r == '=' || r == '>' || r == '<' || r == '!' || r == '~' || r == '|' || r == '^' || r == '&' || r == '%'
 }
 
 func isDot(r rune) bool {
@@ -370,22 +471,26 @@ func lexWhitespace(l *Lexer) stateFn {
 			return nil
 
 		case r == '(':
-			l.next()
 			l.emit(ItemLeftParen)
 			return lexWhitespace
 
 		case r == ')':
-			l.next()
 			l.emit(ItemRightParen)
 			return lexWhitespace
 
+		case r == '[':
+			l.emit(ItemLeftBracket)
+			return lexWhitespace
+
+		case r == ']':
+			l.emit(ItemRightBracket)
+			return lexWhitespace
+
 		case r == ',':
-			l.next()
 			l.emit(ItemComma)
 			return lexWhitespace
 
 		case r == ';':
-			l.next()
 			l.emit(ItemStatementEnd)
 			return lexWhitespace
 
@@ -446,8 +551,13 @@ func lexMultiLineComment(l *Lexer) stateFn {
 }
 
 func lexOperator(l *Lexer) stateFn {
-	operators := "+-*/=><~|^&%"
-	l.acceptRun(operators)
+	// the first rune has already been consumed, check if it begins a longer operator
+	for _, op := range multiCharOperators {
+		if strings.HasPrefix(l.input[l.start:], op) {
+			l.pos = l.start + len(op)
+			break
+		}
+	}
 	l.emit(ItemOperator)
 	return lexWhitespace
 }
@@ -492,6 +602,10 @@ func lexIdentifierOrKeyword(l *Lexer) stateFn {
 				l.backup()
 			}
 			word := l.input[l.start:l.pos]
+			if word == "" {
+				// nothing left after a dot, e.g. the '*' in 'users.*'
+				return lexWhitespace
+			}
 			if _, ok := keywords[strings.ToLower(word)]; ok {
 				l.emit(ItemKeyword)
 			} else {
@@ -505,14 +619,14 @@ func lexIdentifierOrKeyword(l *Lexer) stateFn {
 func lexIdentifierWithBacktick(l *Lexer) stateFn {
 	for {
 		switch r := l.next(); {
-		case isAlphaNumeric(r):
-		// absorb.
 		case isBacktick(r):
 			l.emit(ItemBacktickedIdentifier)
 			return lexWhitespace
-		default:
+		case r == eof || isNewline(r):
 			l.backup()
 			return l.errorf("unterminated backtick")
+		default:
+			// absorb, a quoted identifier may hold any character e.g. `my-project.dataset.table`
 		}
 	}
 }
@@ -20,7 +20,7 @@ func requireItems(t *testing.T, items []Item, tokens ...interface{}) {
 		switch token := tokens[i].(type) {
 		case string:
 			require.Equal(t, tokens[i], item.Val, "index %d: expected item val [%s], got [%s]", i, tokens[i], item.Val)
-		case itemType:
+		case ItemType:
 			require.Equal(t, tokens[i], item.Typ, "index %d: expected item type [%v], got [%v]", i, tokens[i], item.Typ)
 		case Item:
 			require.Equal(t, token, item, "index %d: expected item [%v], got [%v]", i, tokens[i], item)
@@ -107,6 +107,11 @@ func TestLex_SimpleStatementWithWhereClause(t *testing.T) {
 		input := "SELECT * FROM `database`.`table` WHERE `column` = " + `"value";`
 		requireItems(t, testExec(input), "SELECT", "*", "FROM", "`database`", ItemDot, "`table`", "WHERE", "`column`", "=", `"value"`, ItemStatementEnd, ItemEOF)
 	})
+
+	t.Run("backtick with path", func(t *testing.T) {
+		input := "SELECT * FROM `my-project.dataset.table` t"
+		requireItems(t, testExec(input), "SELECT", "*", "FROM", "`my-project.dataset.table`", "t", ItemEOF)
+	})
 }
 
 func TestLex_ParseErrors(t *testing.T) {
@@ -127,3 +132,39 @@ AND bar = 'baz';
 		assert.Equal(t, 2, items[7].Line)
 	})
 }
+
+func TestLex_Operators(t *testing.T) {
+	t.Run("multi character operators", func(t *testing.T) {
+		input := "SELECT * FROM users WHERE a <> 1 AND b >= -2 AND c != 3;"
+		requireItems(t, testExec(input), "SELECT", "*", "FROM", "users", "WHERE", "a", "<>", "1", "AND", "b", ">=", "-", "2", "AND", "c", "!=", "3", ItemStatementEnd, ItemEOF)
+	})
+
+	t.Run("parentheses and commas", func(t *testing.T) {
+		input := "SELECT * FROM users WHERE id IN (1,2,3);"
+		requireItems(t, testExec(input), "SELECT", "*", "FROM", "users", "WHERE", "id", "IN", ItemLeftParen, "1", ItemComma, "2", ItemComma, "3", ItemRightParen, ItemStatementEnd, ItemEOF)
+	})
+
+	t.Run("number at end of input", func(t *testing.T) {
+		input := "SELECT * FROM users WHERE id = 15"
+		requireItems(t, testExec(input), "SELECT", "*", "FROM", "users", "WHERE", "id", "=", "15", ItemEOF)
+	})
+
+	t.Run("table asterisk", func(t *testing.T) {
+		input := "SELECT users.* FROM users;"
+		requireItems(t, testExec(input), "SELECT", "users", ItemDot, "*", "FROM", "users", ItemStatementEnd, ItemEOF)
+	})
+
+	t.Run("brackets", func(t *testing.T) {
+		input := "SELECT items[OFFSET(0)].name FROM t"
+		requireItems(t, testExec(input), "SELECT", "items", ItemLeftBracket, "OFFSET", ItemLeftParen, "0", ItemRightParen, ItemRightBracket, ItemDot, "name", "FROM", "t", ItemEOF)
+	})
+}
+
+func TestIsKeyword(t *testing.T) {
+	items := testExec("select Name")
+	assert.True(t, IsKeyword(items[0], KeywordFrom, KeywordSelect))
+	assert.False(t, IsKeyword(items[0], KeywordFrom))
+	assert.False(t, IsKeyword(items[1], "name"))
+	assert.True(t, IsIdentifier(items[1], "name"))
+	assert.False(t, IsIdentifier(items[0], "select"))
+}
@@ -16,6 +16,7 @@ type Parser[V any] struct {
 	Result *V
 	iter   *collection.Iterator[lex.Item]
 	err    error
+	parent *Parser[V]
 }
 
 func (p *Parser[V]) HasError() bool {
@@ -49,10 +50,25 @@ func (p *Parser[V]) MustPeek() lex.Item {
 	return item
 }
 
+// MustPeekN returns the item n places ahead without consuming it, MustPeekN(1) is the same as MustPeek
+func (p *Parser[V]) MustPeekN(n int) lex.Item {
+	item, ok := p.iter.PeekN(n)
+	if !ok {
+		p.Error(errNoToken)
+		return lex.Item{}
+	}
+	return item
+}
+
 func (p *Parser[V]) Skip() {
 	p.MustNext()
 }
 
+// Backup steps back one item, the next call to MustNext or MustPeek will return it again
+func (p *Parser[V]) Backup() {
+	p.iter.Backup()
+}
+
 func (p *Parser[V]) Get() (*V, error) {
 	return p.Result, p.err
 }
@@ -69,3 +85,29 @@ func NewParser[V any](in string) *Parser[V] {
 		iter:   collection.NewIterator(items...),
 	}
 }
+
+// Nested creates a parser for a statement of another kind nested within the one p is parsing,
+// such as an expression within CREATE TABLE. The nested parser continues reading from p's items,
+// the caller is responsible for reporting its errors through p.
+func Nested[W, V any](p *Parser[V]) *Parser[W] {
+	var result W
+
+	return &Parser[W]{
+		Result: &result,
+		iter:   p.iter,
+	}
+}
+
+// Child creates a parser for a statement of the same kind nested within the one p is parsing,
+// such as a subquery. Like Nested it continues reading from p's items, and it can reach p through
+// Parent, e.g. to resolve names defined by an enclosing statement.
+func (p *Parser[V]) Child() *Parser[V] {
+	child := Nested[V](p)
+	child.parent = p
+	return child
+}
+
+// Parent returns the parser that created p with Child, or nil for a top level parser
+func (p *Parser[V]) Parent() *Parser[V] {
+	return p.parent
+}
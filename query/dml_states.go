@@ -0,0 +1,264 @@
+package query
+
+import (
+	"github.com/ryan-holcombe/sqlparser/lex"
+	"github.com/ryan-holcombe/sqlparser/parse"
+)
+
+func sqlInsert(p *parse.Parser[Query]) parse.StateFn[Query] {
+	var insert Insert
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordInto) {
+		p.Skip()
+	}
+
+	switch name := p.MustNext(); name.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		if !setTableName(p, &insert.Table, name) {
+			return nil
+		}
+	default:
+		return p.Errorf("expected table name within INSERT, found [%s] instead", name.Val)
+	}
+
+	// a column list, unless the rows come from a parenthesized query
+	if p.MustPeek().Typ == lex.ItemLeftParen && !isQueryStart(p) {
+		insert.Columns = identifierList(p)
+	}
+
+	switch next := p.MustPeek(); {
+	case lex.IsIdentifier(next, "values"):
+		p.Skip()
+		insert.Rows = valuesRows(p)
+	case isQueryStart(p):
+		insert.Query = subquery(p)
+	default:
+		return p.Errorf("expected VALUES or a query within INSERT, found [%s] instead", next.Val)
+	}
+	if p.HasError() {
+		return nil
+	}
+
+	return setInsert(p, insert, nil)
+}
+
+func sqlUpdate(p *parse.Parser[Query]) parse.StateFn[Query] {
+	update := Update{Table: targetTable(p, "UPDATE")}
+	if p.HasError() || !expectKeyword(p, lex.KeywordSet) {
+		return nil
+	}
+
+	update.Assignments = assignments(p)
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordWhere) {
+		p.Skip()
+		update.Where = expression(p)
+	}
+	if p.HasError() {
+		return nil
+	}
+
+	return setUpdate(p, update, nil)
+}
+
+// targetTable parses the table modified by a statement, which is a possibly qualified table name
+// with an optional alias rather than any table source of a FROM clause
+func targetTable(p *parse.Parser[Query], stmt string) Table {
+	var tbl Table
+	switch name := p.MustNext(); name.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		if !setTableName(p, &tbl, name) {
+			return Table{}
+		}
+	default:
+		p.Errorf("expected table name within %s, found [%s] instead", stmt, name.Val)
+		return Table{}
+	}
+
+	alias, ok := optionalAlias(p)
+	if !ok {
+		return Table{}
+	}
+	tbl.Alias = alias
+	return tbl
+}
+
+func sqlDelete(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordFrom) {
+		p.Skip()
+	}
+
+	del := Delete{Table: targetTable(p, "DELETE")}
+	if !p.HasError() && lex.IsKeyword(p.MustPeek(), lex.KeywordWhere) {
+		p.Skip()
+		del.Where = expression(p)
+	}
+	if p.HasError() {
+		return nil
+	}
+
+	return setDelete(p, del, nil)
+}
+
+func sqlMerge(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordInto) {
+		p.Skip()
+	}
+
+	merge := Merge{Target: targetTable(p, "MERGE")}
+	if p.HasError() || !expectKeyword(p, lex.KeywordUsing) {
+		return nil
+	}
+	merge.Source = tableRef(p)
+	if !expectKeyword(p, lex.KeywordOn) {
+		return nil
+	}
+	merge.On = expression(p)
+	if p.HasError() {
+		return nil
+	}
+
+	p.Result.Merge = &merge
+	return sqlMergeWhen
+}
+
+func sqlMergeWhen(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordWhen) {
+		if !parse.Validate(p.Result.Merge) {
+			return p.Errorf("invalid MERGE statement found for table [%s]", p.Result.Merge.Target.Name)
+		}
+		return nil
+	}
+	p.Skip()
+
+	clause := MergeClause{Match: MergeMatched}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordNot) {
+		p.Skip()
+		clause.Match = MergeNotMatchedByTarget
+	}
+	if next := p.MustNext(); !lex.IsIdentifier(next, "matched") {
+		return p.Errorf("expected MATCHED within WHEN, found [%s] instead", next.Val)
+	}
+	if clause.Match == MergeNotMatchedByTarget && lex.IsKeyword(p.MustPeek(), lex.KeywordBy) {
+		p.Skip()
+		switch next := p.MustNext(); {
+		case lex.IsIdentifier(next, "target"):
+		case lex.IsIdentifier(next, "source"):
+			clause.Match = MergeNotMatchedBySource
+		default:
+			return p.Errorf("expected TARGET or SOURCE after NOT MATCHED BY, found [%s] instead", next.Val)
+		}
+	}
+
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordAnd) {
+		p.Skip()
+		clause.Condition = expression(p)
+	}
+	if !expectKeyword(p, lex.KeywordThen) {
+		return nil
+	}
+
+	switch next := p.MustNext(); {
+	case lex.IsIdentifier(next, MergeUpdate.String()):
+		clause.Action = MergeUpdate
+		if !expectKeyword(p, lex.KeywordSet) {
+			return nil
+		}
+		clause.Assignments = assignments(p)
+	case lex.IsIdentifier(next, MergeDelete.String()):
+		clause.Action = MergeDelete
+	case lex.IsIdentifier(next, MergeInsert.String()):
+		clause.Action = MergeInsert
+		if p.MustPeek().Typ == lex.ItemLeftParen {
+			clause.Columns = identifierList(p)
+		}
+		switch next := p.MustNext(); {
+		case lex.IsIdentifier(next, "values"):
+			clause.Values = valuesRow(p)
+		case lex.IsIdentifier(next, "row"):
+		default:
+			return p.Errorf("expected VALUES or ROW within INSERT, found [%s] instead", next.Val)
+		}
+	default:
+		return p.Errorf("expected UPDATE, DELETE or INSERT within WHEN, found [%s] instead", next.Val)
+	}
+	if p.HasError() {
+		return nil
+	}
+
+	return addMergeClause(p, clause, sqlMergeWhen)
+}
+
+// assignments parses the comma separated `column = value` items of a SET clause
+func assignments(p *parse.Parser[Query]) []Assignment {
+	var list []Assignment
+	for !p.HasError() {
+		var assignment Assignment
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+			column, ok := exprColumnRef(p, next).(ColumnRef)
+			if !ok {
+				p.Errorf("expected column name within SET, found [%s] instead", next.Val)
+				return nil
+			}
+			assignment.Column = column
+		default:
+			p.Errorf("expected column name within SET, found [%s] instead", next.Val)
+			return nil
+		}
+
+		if next := p.MustNext(); !isOperator(next, "=") {
+			p.Errorf("expected '=' after column [%s] within SET, found [%s] instead", assignment.Column.Column, next.Val)
+			return nil
+		}
+		assignment.Value = valueExpr(p)
+		list = append(list, assignment)
+
+		if p.MustPeek().Typ != lex.ItemComma {
+			return list
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// valuesRows parses the comma separated rows of a VALUES list
+func valuesRows(p *parse.Parser[Query]) [][]Expr {
+	var rows [][]Expr
+	for !p.HasError() {
+		rows = append(rows, valuesRow(p))
+		if p.MustPeek().Typ != lex.ItemComma {
+			return rows
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// valuesRow parses a single parenthesized row of values, where DEFAULT may stand in for any value
+func valuesRow(p *parse.Parser[Query]) []Expr {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+
+	var row []Expr
+	for !p.HasError() {
+		row = append(row, valueExpr(p))
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return row
+		default:
+			p.Errorf("expected ',' or ')' within VALUES, found [%s] instead", next.Val)
+		}
+	}
+	return nil
+}
+
+// valueExpr parses an expression that is being written to a column, which may be DEFAULT
+func valueExpr(p *parse.Parser[Query]) Expr {
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordDefault) {
+		p.Skip()
+		return Default{}
+	}
+	return expression(p)
+}
@@ -0,0 +1,345 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/ryan-holcombe/sqlparser/types"
+)
+
+// Expr is a node within an expression tree, e.g. the predicate of a WHERE clause
+type Expr interface {
+	expr()
+}
+
+type LiteralKind string
+
+func (k LiteralKind) String() string {
+	return string(k)
+}
+
+const (
+	LiteralNumber LiteralKind = "NUMBER"
+	LiteralString LiteralKind = "STRING"
+	LiteralBool   LiteralKind = "BOOL"
+	LiteralNull   LiteralKind = "NULL"
+)
+
+// Literal is a constant value. Val holds the raw value, strings include their quotes.
+type Literal struct {
+	Kind LiteralKind
+	Val  string
+}
+
+// Type returns the type of the literal's value, it is empty for NULL which has no type of its own
+func (l Literal) Type() types.ColumnType {
+	switch l.Kind {
+	case LiteralNumber:
+		if strings.ContainsAny(l.Val, ".eE") && !strings.HasPrefix(strings.ToLower(l.Val), "0x") {
+			return types.ColumnTypeFloat64
+		}
+		return types.ColumnTypeInt64
+	case LiteralString:
+		return types.ColumnTypeString
+	case LiteralBool:
+		return types.ColumnTypeBool
+	default:
+		return ""
+	}
+}
+
+// TypedLiteral is a string literal of another type, such as `DATE '2024-01-01'` or
+// `JSON '{"a": 1}'`. Val holds the raw string, including its quotes.
+type TypedLiteral struct {
+	Type types.ColumnType
+	Val  string
+}
+
+// ArrayLiteral is an array constructor such as `[1, 2, 3]`. Type is set when the array type is
+// given explicitly, as in `ARRAY<INT64>[1, 2]`.
+type ArrayLiteral struct {
+	Type  *types.Type
+	Elems []Expr
+}
+
+// StructLiteral is a struct constructor such as `STRUCT(1 AS a, 'x' AS b)`, the alias of each
+// field is its name. Type is set when the struct type is given explicitly, as in
+// `STRUCT<a INT64>(1)`.
+type StructLiteral struct {
+	Type   *types.Type
+	Fields []Column
+}
+
+// Tuple is a parenthesized list of two or more values such as `(1, 'x')`
+type Tuple struct {
+	Elems []Expr
+}
+
+// ColumnRef is a reference to a column by a dotted name. Which parts name a table, a column or a
+// field read from a struct column depends on the tables in scope, so the parser only splits the
+// name and leaves that resolution to the caller:
+//   - `name` is the column name, Column is set
+//   - `t.name` is Table and Column, where a caller that finds no table or alias named t reads it
+//     as the field name of column t instead, e.g. `item.name` on an UNNEST alias item
+//   - a name of three or more parts, such as `dataset.users.name` or `t.s.field`, is kept whole
+//     in Path with Table and Column left empty, the caller matches its leading parts against the
+//     tables in scope and reads any parts after the column as fields
+//
+// Names are stored without their backticks.
+type ColumnRef struct {
+	Table  string
+	Column string
+	Path   []string
+}
+
+// Parts returns every part of the reference in order
+func (c ColumnRef) Parts() []string {
+	switch {
+	case c.Path != nil:
+		return c.Path
+	case c.Table == "":
+		return []string{c.Column}
+	default:
+		return []string{c.Table, c.Column}
+	}
+}
+
+// Star is the `*` wildcard, optionally qualified by its table as in `users.*`. Except lists the
+// columns left out by `* EXCEPT (a, b)` and Replace the columns substituted by
+// `* REPLACE (expr AS a)`, the alias of each replacement names the column it replaces.
+type Star struct {
+	Table   string
+	Except  []string
+	Replace []Column
+}
+
+// FuncCall is a call to a function such as COUNT(*) or UPPER(name). Name includes any qualifier,
+// e.g. SAFE.DIVIDE. Distinct, Nulls, OrderBy and Limit are the modifiers of aggregate functions,
+// as in ARRAY_AGG(DISTINCT x IGNORE NULLS ORDER BY y LIMIT 10). Over is set for an analytic
+// function call.
+type FuncCall struct {
+	Name     string
+	Distinct bool
+	Args     []Expr
+	Nulls    NullHandling
+	OrderBy  []OrderItem
+	Limit    Expr
+	Over     *WindowSpec
+}
+
+type NullHandling string
+
+func (n NullHandling) String() string {
+	return string(n)
+}
+
+const (
+	IgnoreNulls  NullHandling = "IGNORE NULLS"
+	RespectNulls NullHandling = "RESPECT NULLS"
+)
+
+// NamedArg is a function argument passed by name, as in `name => value`
+type NamedArg struct {
+	Name  string
+	Value Expr
+}
+
+// WindowSpec is the window of an analytic function call. Name refers to a window of the WINDOW
+// clause, which the rest of the specification refines, as in `OVER w` or `OVER (w ORDER BY x)`.
+type WindowSpec struct {
+	Name        string
+	PartitionBy []Expr
+	OrderBy     []OrderItem
+	Frame       *Frame
+}
+
+type FrameUnit string
+
+func (u FrameUnit) String() string {
+	return string(u)
+}
+
+const (
+	FrameRows   FrameUnit = "ROWS"
+	FrameRange  FrameUnit = "RANGE"
+	FrameGroups FrameUnit = "GROUPS"
+)
+
+// Frame is the frame clause of a window, End is nil unless the frame is given with BETWEEN
+type Frame struct {
+	Unit  FrameUnit
+	Start FrameBound
+	End   *FrameBound
+}
+
+type FrameBoundKind string
+
+func (k FrameBoundKind) String() string {
+	return string(k)
+}
+
+const (
+	FrameUnboundedPreceding FrameBoundKind = "UNBOUNDED PRECEDING"
+	FramePreceding          FrameBoundKind = "PRECEDING"
+	FrameCurrentRow         FrameBoundKind = "CURRENT ROW"
+	FrameFollowing          FrameBoundKind = "FOLLOWING"
+	FrameUnboundedFollowing FrameBoundKind = "UNBOUNDED FOLLOWING"
+)
+
+// FrameBound is the start or end of a window frame, Offset is set for PRECEDING and FOLLOWING
+type FrameBound struct {
+	Kind   FrameBoundKind
+	Offset Expr
+}
+
+// Case is a CASE expression. Operand is set for a simple CASE, which compares it with the
+// condition of each WHEN, a searched CASE evaluates the conditions instead.
+type Case struct {
+	Operand Expr
+	Whens   []When
+	Else    Expr
+}
+
+// When is a `WHEN condition THEN result` branch of a CASE expression
+type When struct {
+	Cond   Expr
+	Result Expr
+}
+
+// Cast is a `CAST(expr AS type)` conversion, Safe is set for SAFE_CAST. Any well formed type is
+// accepted, e.g. DATETIME or GEOGRAPHY, callers check Type.Known when they need one of the
+// types.ColumnType constants. The same holds for the Type of ArrayLiteral and StructLiteral.
+type Cast struct {
+	Expr Expr
+	Type types.Type
+	Safe bool
+}
+
+// Extract is an `EXTRACT(part FROM expr [AT TIME ZONE tz])` call, Part is the date part such as
+// YEAR or WEEK(MONDAY)
+type Extract struct {
+	Part     string
+	Expr     Expr
+	TimeZone Expr
+}
+
+// Interval is an interval literal such as `INTERVAL 5 DAY`. To is set for a range of date parts,
+// as in `INTERVAL '1-2' YEAR TO MONTH`.
+type Interval struct {
+	Expr Expr
+	Unit string
+	To   string
+}
+
+type IndexKind string
+
+func (k IndexKind) String() string {
+	return string(k)
+}
+
+const (
+	IndexOffset      IndexKind = "OFFSET"
+	IndexOrdinal     IndexKind = "ORDINAL"
+	IndexSafeOffset  IndexKind = "SAFE_OFFSET"
+	IndexSafeOrdinal IndexKind = "SAFE_ORDINAL"
+)
+
+// Index is an array element access such as `arr[OFFSET(1)]`, Kind is empty for a bare subscript
+// as in `arr[1]`
+type Index struct {
+	Expr  Expr
+	Kind  IndexKind
+	Index Expr
+}
+
+// FieldAccess reads a field of a STRUCT value that is not a column, as in `arr[OFFSET(0)].name`
+type FieldAccess struct {
+	Expr  Expr
+	Field string
+}
+
+// Subquery is a parenthesized query used as a scalar value
+type Subquery struct {
+	Query *Query
+}
+
+// Exists is an `EXISTS (subquery)` test
+type Exists struct {
+	Query *Query
+}
+
+// Default is the DEFAULT keyword used as a value, e.g. within INSERT ... VALUES
+type Default struct{}
+
+// Unary is an operator applied to a single expression: NOT, -, +, ~
+type Unary struct {
+	Op   string
+	Expr Expr
+}
+
+// Binary is an operator applied to two expressions: AND, OR, =, <, +, etc.
+type Binary struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+// Paren is an expression wrapped in parentheses
+type Paren struct {
+	Expr Expr
+}
+
+// IsNull is an `expr IS [NOT] NULL` test
+type IsNull struct {
+	Expr Expr
+	Not  bool
+}
+
+// Between is an `expr [NOT] BETWEEN low AND high` test
+type Between struct {
+	Expr Expr
+	Low  Expr
+	High Expr
+	Not  bool
+}
+
+// Like is an `expr [NOT] LIKE pattern` test
+type Like struct {
+	Expr    Expr
+	Pattern Expr
+	Not     bool
+}
+
+// In is an `expr [NOT] IN (value, ...)` or `expr [NOT] IN (subquery)` test, only one of List
+// and Query is set
+type In struct {
+	Expr  Expr
+	List  []Expr
+	Query *Query
+	Not   bool
+}
+
+func (Literal) expr()       {}
+func (TypedLiteral) expr()  {}
+func (ArrayLiteral) expr()  {}
+func (StructLiteral) expr() {}
+func (Tuple) expr()         {}
+func (ColumnRef) expr()     {}
+func (Star) expr()          {}
+func (FuncCall) expr()      {}
+func (NamedArg) expr()      {}
+func (Case) expr()          {}
+func (Cast) expr()          {}
+func (Extract) expr()       {}
+func (Interval) expr()      {}
+func (Index) expr()         {}
+func (FieldAccess) expr()   {}
+func (Subquery) expr()      {}
+func (Exists) expr()        {}
+func (Default) expr()       {}
+func (Unary) expr()         {}
+func (Binary) expr()        {}
+func (Paren) expr()         {}
+func (IsNull) expr()        {}
+func (Between) expr()       {}
+func (Like) expr()          {}
+func (In) expr()            {}
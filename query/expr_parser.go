@@ -0,0 +1,849 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/ryan-holcombe/sqlparser/lex"
+	"github.com/ryan-holcombe/sqlparser/parse"
+	"github.com/ryan-holcombe/sqlparser/types"
+)
+
+// comparisonOperators compare two values, they bind looser than any other binary operator
+var comparisonOperators = []string{"=", "<>", "!=", "<", "<=", ">", ">="}
+
+// binaryOperators are grouped from the lowest to the highest precedence
+var binaryOperators = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "%", "||"},
+}
+
+// unaryOperators may prefix any operand
+var unaryOperators = []string{"-", "+", "~"}
+
+// typedLiterals are the types that may prefix a string literal, as in DATE '2024-01-01'
+var typedLiterals = []string{
+	types.ColumnTypeDate.String(), types.ColumnTypeTimestamp.String(), types.ColumnTypeJSON.String(), types.ColumnTypeNumeric.String(),
+}
+
+// functionKeywords are reserved keywords that are also the names of functions
+var functionKeywords = []string{lex.KeywordIf, lex.KeywordLeft, lex.KeywordRight, lex.KeywordGrouping, lex.KeywordRange}
+
+// expression parses a full expression. It stops at the first item that cannot continue
+// the expression, leaving that item to be read by the caller.
+func expression(p *parse.Parser[Query]) Expr {
+	return exprOr(p)
+}
+
+func exprOr(p *parse.Parser[Query]) Expr {
+	left := exprAnd(p)
+	for !p.HasError() && lex.IsKeyword(p.MustPeek(), lex.KeywordOr) {
+		p.Skip()
+		left = Binary{Op: "OR", Left: left, Right: exprAnd(p)}
+	}
+	return left
+}
+
+func exprAnd(p *parse.Parser[Query]) Expr {
+	left := exprNot(p)
+	for !p.HasError() && lex.IsKeyword(p.MustPeek(), lex.KeywordAnd) {
+		p.Skip()
+		left = Binary{Op: "AND", Left: left, Right: exprNot(p)}
+	}
+	return left
+}
+
+func exprNot(p *parse.Parser[Query]) Expr {
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordNot) {
+		p.Skip()
+		return Unary{Op: "NOT", Expr: exprNot(p)}
+	}
+	return exprComparison(p)
+}
+
+func exprComparison(p *parse.Parser[Query]) Expr {
+	left := exprBinary(p, 0)
+	if p.HasError() {
+		return nil
+	}
+
+	next := p.MustNext()
+	not := lex.IsKeyword(next, lex.KeywordNot)
+	if not {
+		next = p.MustNext()
+	}
+
+	switch {
+	case !not && isOperator(next, comparisonOperators...):
+		return Binary{Op: next.Val, Left: left, Right: exprBinary(p, 0)}
+	case !not && lex.IsKeyword(next, lex.KeywordIs):
+		isNull := IsNull{Expr: left, Not: lex.IsKeyword(p.MustPeek(), lex.KeywordNot)}
+		if isNull.Not {
+			p.Skip()
+		}
+		if next := p.MustNext(); !lex.IsKeyword(next, lex.KeywordNull) {
+			return exprErrorf(p, "expected NULL after IS, found [%s] instead", next.Val)
+		}
+		return isNull
+	case lex.IsKeyword(next, lex.KeywordBetween):
+		between := Between{Expr: left, Low: exprBinary(p, 0), Not: not}
+		if next := p.MustNext(); !lex.IsKeyword(next, lex.KeywordAnd) {
+			return exprErrorf(p, "expected AND within BETWEEN, found [%s] instead", next.Val)
+		}
+		between.High = exprBinary(p, 0)
+		return between
+	case lex.IsKeyword(next, lex.KeywordLike):
+		return Like{Expr: left, Pattern: exprBinary(p, 0), Not: not}
+	case lex.IsKeyword(next, lex.KeywordIn):
+		if query, ok := parenQuery(p); ok {
+			return In{Expr: left, Query: query, Not: not}
+		}
+		return In{Expr: left, List: exprList(p), Not: not}
+	case not:
+		return exprErrorf(p, "expected BETWEEN, LIKE or IN after NOT, found [%s] instead", next.Val)
+	default:
+		p.Backup()
+		return left
+	}
+}
+
+// exprBinary parses the binary operators found at the given level of binaryOperators,
+// operators found at the same level are left associative
+func exprBinary(p *parse.Parser[Query], level int) Expr {
+	if level == len(binaryOperators) {
+		return exprUnary(p)
+	}
+
+	left := exprBinary(p, level+1)
+	for !p.HasError() && isOperator(p.MustPeek(), binaryOperators[level]...) {
+		op := p.MustNext()
+		left = Binary{Op: op.Val, Left: left, Right: exprBinary(p, level+1)}
+	}
+	return left
+}
+
+func exprUnary(p *parse.Parser[Query]) Expr {
+	if peek := p.MustPeek(); isOperator(peek, unaryOperators...) {
+		p.Skip()
+		return Unary{Op: peek.Val, Expr: exprUnary(p)}
+	}
+	return exprPostfix(p, exprPrimary(p))
+}
+
+func exprPrimary(p *parse.Parser[Query]) Expr {
+	switch next := p.MustNext(); {
+	case next.Typ == lex.ItemNumber:
+		return Literal{Kind: LiteralNumber, Val: next.Val}
+	case next.Typ == lex.ItemString:
+		return Literal{Kind: LiteralString, Val: next.Val}
+	case lex.IsKeyword(next, lex.KeywordTrue, lex.KeywordFalse):
+		return Literal{Kind: LiteralBool, Val: strings.ToUpper(next.Val)}
+	case lex.IsKeyword(next, lex.KeywordNull):
+		return Literal{Kind: LiteralNull, Val: strings.ToUpper(next.Val)}
+	case lex.IsIdentifier(next, typedLiterals...) && p.MustPeek().Typ == lex.ItemString:
+		return TypedLiteral{Type: types.ColumnType(strings.ToUpper(next.Val)), Val: p.MustNext().Val}
+	case next.Typ == lex.ItemLeftBracket:
+		return exprArray(p, nil)
+	case lex.IsKeyword(next, lex.KeywordArray):
+		return exprArrayConstructor(p)
+	case lex.IsKeyword(next, lex.KeywordStruct):
+		return exprStruct(p)
+	case lex.IsKeyword(next, lex.KeywordCase):
+		return exprCase(p)
+	case lex.IsKeyword(next, lex.KeywordCast):
+		return exprCast(p, false)
+	case lex.IsIdentifier(next, "safe_cast") && p.MustPeek().Typ == lex.ItemLeftParen:
+		return exprCast(p, true)
+	case lex.IsKeyword(next, lex.KeywordExtract):
+		return exprExtract(p)
+	case lex.IsKeyword(next, lex.KeywordInterval):
+		return exprInterval(p)
+	case next.Typ == lex.ItemIdentifier && p.MustPeek().Typ == lex.ItemLeftParen,
+		lex.IsKeyword(next, functionKeywords...) && p.MustPeek().Typ == lex.ItemLeftParen:
+		return exprFuncCall(p, next.Val)
+	case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier:
+		return exprColumnRef(p, next)
+	case lex.IsKeyword(next, lex.KeywordExists):
+		query, ok := parenQuery(p)
+		if !ok {
+			return exprErrorf(p, "expected subquery after EXISTS, found [%s] instead", p.MustPeek().Val)
+		}
+		return Exists{Query: query}
+	case next.Typ == lex.ItemLeftParen && isQueryStart(p):
+		p.Backup()
+		query, _ := parenQuery(p)
+		return Subquery{Query: query}
+	case next.Typ == lex.ItemLeftParen:
+		first := expression(p)
+		if p.MustPeek().Typ == lex.ItemComma { // a tuple
+			p.Skip()
+			tuple := Tuple{Elems: append([]Expr{first}, expressions(p)...)}
+			if !expectItem(p, lex.ItemRightParen) {
+				return nil
+			}
+			return tuple
+		}
+
+		paren := Paren{Expr: first}
+		if !expectItem(p, lex.ItemRightParen) {
+			return nil
+		}
+		return paren
+	default:
+		return exprErrorf(p, "unsupported next type [%v] found within [%s]", next.Typ, "expression")
+	}
+}
+
+// exprColumnRef parses a column reference, the first identifier has already been read
+func exprColumnRef(p *parse.Parser[Query], first lex.Item) Expr {
+	parts := namePath(p, first)
+	switch {
+	case parts == nil:
+		return nil
+	case len(parts) > 1 && p.MustPeek().Typ == lex.ItemLeftParen: // a qualified function name
+		return exprFuncCall(p, strings.Join(parts, "."))
+	case len(parts) == 1:
+		return ColumnRef{Column: parts[0]}
+	case len(parts) == 2:
+		return ColumnRef{Table: parts[0], Column: parts[1]}
+	default:
+		return ColumnRef{Path: parts}
+	}
+}
+
+// namePath reads the dotted parts of a name, the first of which has already been read. A
+// backticked identifier is split into its parts in the same way, so `a.b`.c and a.b.c give the
+// same path. It stops before a `.*`, which is left to the caller.
+func namePath(p *parse.Parser[Query], first lex.Item) []string {
+	parts := nameParts(first)
+	for p.MustPeek().Typ == lex.ItemDot && !isOperator(p.MustPeekN(2), ColumnAsterisk) {
+		p.Skip()
+		switch next := p.MustNext(); {
+		case next.Typ == lex.ItemBacktickedIdentifier:
+			parts = append(parts, nameParts(next)...)
+		case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemKeyword:
+			parts = append(parts, next.Val)
+		default:
+			p.Errorf("expected name after [%s.], found [%s] instead", strings.Join(parts, "."), next.Val)
+			return nil
+		}
+	}
+	return parts
+}
+
+// nameParts returns the parts of a single identifier, removing the quotes of a backticked one
+func nameParts(item lex.Item) []string {
+	if item.Typ != lex.ItemBacktickedIdentifier {
+		return []string{item.Val}
+	}
+	return strings.Split(identifier(item), ".")
+}
+
+// identifier returns the name held by an identifier item. Every name stored in a Query goes
+// through it, so the backticks of a quoted name such as `order` are never kept.
+func identifier(item lex.Item) string {
+	return strings.Trim(item.Val, "`")
+}
+
+// exprPostfix parses the array subscripts and field accesses that follow an operand, as in
+// `items[OFFSET(0)].name`
+func exprPostfix(p *parse.Parser[Query], expr Expr) Expr {
+	for expr != nil && !p.HasError() {
+		switch p.MustPeek().Typ {
+		case lex.ItemLeftBracket:
+			p.Skip()
+			expr = exprSubscript(p, expr)
+		case lex.ItemDot:
+			p.Skip()
+			name, ok := fieldName(p)
+			if !ok {
+				return exprErrorf(p, "expected field name after [.], found [%s] instead", name)
+			}
+			expr = FieldAccess{Expr: expr, Field: name}
+		default:
+			return expr
+		}
+	}
+	return expr
+}
+
+// exprSubscript parses an array subscript, the '[' has already been read
+func exprSubscript(p *parse.Parser[Query], expr Expr) Expr {
+	index := Index{Expr: expr}
+	if next := p.MustNext(); lex.IsIdentifier(next, IndexOffset.String(), IndexOrdinal.String(), IndexSafeOffset.String(), IndexSafeOrdinal.String()) &&
+		p.MustPeek().Typ == lex.ItemLeftParen {
+		p.Skip()
+		index.Kind = IndexKind(strings.ToUpper(next.Val))
+		index.Index = expression(p)
+		if !expectItem(p, lex.ItemRightParen) {
+			return nil
+		}
+	} else {
+		p.Backup()
+		index.Index = expression(p)
+	}
+
+	if !expectItem(p, lex.ItemRightBracket) {
+		return nil
+	}
+	return index
+}
+
+// fieldName reads the name following a '.', keywords are allowed as nothing else may follow it.
+// When the next item is not a name it is returned with ok set to false.
+func fieldName(p *parse.Parser[Query]) (name string, ok bool) {
+	switch next := p.MustNext(); next.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier, lex.ItemKeyword:
+		return identifier(next), true
+	default:
+		return next.Val, false
+	}
+}
+
+// exprFuncCall parses the arguments and modifiers of a function call, the function name has
+// already been read
+func exprFuncCall(p *parse.Parser[Query], name string) Expr {
+	call := FuncCall{Name: name}
+	p.Skip() // skip the '('
+
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordDistinct) {
+		p.Skip()
+		call.Distinct = true
+	}
+
+	switch peek := p.MustPeek(); {
+	case peek.Typ == lex.ItemRightParen:
+	case isOperator(peek, ColumnAsterisk): // COUNT(*)
+		p.Skip()
+		call.Args = []Expr{Star{}}
+	default:
+		call.Args = funcArgs(p)
+	}
+
+	if next := p.MustPeek(); lex.IsKeyword(next, lex.KeywordIgnore, lex.KeywordRespect) {
+		p.Skip()
+		if !expectKeyword(p, lex.KeywordNulls) {
+			return nil
+		}
+		call.Nulls = NullHandling(strings.ToUpper(next.Val) + " NULLS")
+	}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordOrder) {
+		p.Skip()
+		if !expectKeyword(p, lex.KeywordBy) {
+			return nil
+		}
+		call.OrderBy = orderItems(p)
+	}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordLimit) {
+		p.Skip()
+		call.Limit = expression(p)
+	}
+
+	if !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+	return exprOver(p, call)
+}
+
+// funcArgs parses the comma separated arguments of a function call, each either an expression
+// or a named argument
+func funcArgs(p *parse.Parser[Query]) []Expr {
+	var args []Expr
+	for !p.HasError() {
+		if name := p.MustNext(); name.Typ == lex.ItemIdentifier && isOperator(p.MustPeek(), "=>") {
+			p.Skip()
+			args = append(args, NamedArg{Name: name.Val, Value: expression(p)})
+		} else {
+			p.Backup()
+			args = append(args, expression(p))
+		}
+
+		if p.MustPeek().Typ != lex.ItemComma {
+			return args
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// exprArrayConstructor parses an array literal following ARRAY, which is either `ARRAY[...]` or
+// given its type as in `ARRAY<INT64>[...]`
+func exprArrayConstructor(p *parse.Parser[Query]) Expr {
+	var typ *types.Type
+	if isOperator(p.MustPeek(), "<") {
+		p.Backup() // the type begins with ARRAY
+		t, ok := types.Parse(p)
+		if !ok {
+			return nil
+		}
+		typ = &t
+	}
+
+	if !expectItem(p, lex.ItemLeftBracket) {
+		return nil
+	}
+	return exprArray(p, typ)
+}
+
+// exprArray parses the elements of an array literal, the '[' has already been read
+func exprArray(p *parse.Parser[Query], typ *types.Type) Expr {
+	array := ArrayLiteral{Type: typ}
+	if p.MustPeek().Typ != lex.ItemRightBracket {
+		array.Elems = expressions(p)
+	}
+	if !expectItem(p, lex.ItemRightBracket) {
+		return nil
+	}
+	return array
+}
+
+// exprStruct parses a struct literal following STRUCT, e.g. `STRUCT(1 AS a)` or `STRUCT<a INT64>(1)`
+func exprStruct(p *parse.Parser[Query]) Expr {
+	var s StructLiteral
+	if isOperator(p.MustPeek(), "<") {
+		p.Backup() // the type begins with STRUCT
+		t, ok := types.Parse(p)
+		if !ok {
+			return nil
+		}
+		s.Type = &t
+	}
+
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	if p.MustPeek().Typ == lex.ItemRightParen {
+		p.Skip()
+		return s
+	}
+	for !p.HasError() {
+		field := Column{Expr: expression(p)}
+		if lex.IsKeyword(p.MustPeek(), lex.KeywordAs) {
+			p.Skip()
+			switch alias := p.MustNext(); alias.Typ {
+			case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+				field.Alias = identifier(alias)
+			default:
+				return exprErrorf(p, "expected field name within STRUCT, found [%s] instead", alias.Val)
+			}
+		}
+		s.Fields = append(s.Fields, field)
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return s
+		default:
+			return exprErrorf(p, "expected ',' or ')' within STRUCT, found [%s] instead", next.Val)
+		}
+	}
+	return nil
+}
+
+// exprCase parses a simple or searched CASE expression, CASE has already been read
+func exprCase(p *parse.Parser[Query]) Expr {
+	var c Case
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordWhen) {
+		c.Operand = expression(p)
+	}
+
+	for lex.IsKeyword(p.MustPeek(), lex.KeywordWhen) && !p.HasError() {
+		p.Skip()
+		when := When{Cond: expression(p)}
+		if !expectKeyword(p, lex.KeywordThen) {
+			return nil
+		}
+		when.Result = expression(p)
+		c.Whens = append(c.Whens, when)
+	}
+	if len(c.Whens) == 0 {
+		return exprErrorf(p, "expected WHEN within CASE, found [%s] instead", p.MustPeek().Val)
+	}
+
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordElse) {
+		p.Skip()
+		c.Else = expression(p)
+	}
+	if !expectKeyword(p, lex.KeywordEnd) {
+		return nil
+	}
+	return c
+}
+
+// exprCast parses the parenthesized body of CAST or SAFE_CAST
+func exprCast(p *parse.Parser[Query], safe bool) Expr {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	cast := Cast{Expr: expression(p), Safe: safe}
+	if !expectKeyword(p, lex.KeywordAs) {
+		return nil
+	}
+
+	typ, ok := types.Parse(p)
+	if !ok {
+		return nil
+	}
+	cast.Type = typ
+
+	if !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+	return cast
+}
+
+// exprExtract parses the parenthesized body of EXTRACT
+func exprExtract(p *parse.Parser[Query]) Expr {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	var extract Extract
+	if extract.Part = datePart(p); extract.Part == "" {
+		return nil
+	}
+	if p.MustPeek().Typ == lex.ItemLeftParen { // WEEK(MONDAY)
+		p.Skip()
+		day := datePart(p)
+		if !expectItem(p, lex.ItemRightParen) {
+			return nil
+		}
+		extract.Part += "(" + day + ")"
+	}
+
+	if !expectKeyword(p, lex.KeywordFrom) {
+		return nil
+	}
+	extract.Expr = expression(p)
+
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordAt) {
+		p.Skip()
+		if time, zone := p.MustNext(), p.MustNext(); !lex.IsIdentifier(time, "time") || !lex.IsIdentifier(zone, "zone") {
+			return exprErrorf(p, "expected [TIME ZONE] after AT, found [%s %s] instead", time.Val, zone.Val)
+		}
+		extract.TimeZone = expression(p)
+	}
+
+	if !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+	return extract
+}
+
+// exprInterval parses the value and date part of an INTERVAL literal
+func exprInterval(p *parse.Parser[Query]) Expr {
+	interval := Interval{Expr: expression(p)}
+	if interval.Unit = datePart(p); interval.Unit == "" {
+		return nil
+	}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordTo) {
+		p.Skip()
+		if interval.To = datePart(p); interval.To == "" {
+			return nil
+		}
+	}
+	return interval
+}
+
+// datePart reads a date part such as YEAR or DAY, recording an error if the next item is not one
+func datePart(p *parse.Parser[Query]) string {
+	next := p.MustNext()
+	if next.Typ != lex.ItemIdentifier {
+		p.Errorf("expected date part, found [%s] instead", next.Val)
+		return ""
+	}
+	return strings.ToUpper(next.Val)
+}
+
+// exprOver parses the OVER clause following a function call, when there is one
+func exprOver(p *parse.Parser[Query], call FuncCall) Expr {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordOver) {
+		return call
+	}
+	p.Skip()
+
+	switch next := p.MustPeek(); next.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier: // a reference to a named window
+		p.Skip()
+		call.Over = &WindowSpec{Name: identifier(next)}
+	default:
+		spec := windowSpec(p)
+		call.Over = &spec
+	}
+	return call
+}
+
+// windowSpec parses a parenthesized window specification
+func windowSpec(p *parse.Parser[Query]) WindowSpec {
+	var spec WindowSpec
+	if !expectItem(p, lex.ItemLeftParen) {
+		return spec
+	}
+
+	if next := p.MustPeek(); next.Typ == lex.ItemIdentifier || next.Typ == lex.ItemBacktickedIdentifier {
+		p.Skip()
+		spec.Name = identifier(next)
+	}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordPartition) {
+		p.Skip()
+		if !expectKeyword(p, lex.KeywordBy) {
+			return spec
+		}
+		spec.PartitionBy = expressions(p)
+	}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordOrder) {
+		p.Skip()
+		if !expectKeyword(p, lex.KeywordBy) {
+			return spec
+		}
+		spec.OrderBy = orderItems(p)
+	}
+	if next := p.MustPeek(); lex.IsKeyword(next, lex.KeywordRows, lex.KeywordRange, lex.KeywordGroups) {
+		p.Skip()
+		spec.Frame = windowFrame(p, FrameUnit(strings.ToUpper(next.Val)))
+	}
+
+	expectItem(p, lex.ItemRightParen)
+	return spec
+}
+
+// windowFrame parses the extent of a window frame, its unit has already been read
+func windowFrame(p *parse.Parser[Query], unit FrameUnit) *Frame {
+	frame := Frame{Unit: unit}
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordBetween) {
+		frame.Start = frameBound(p)
+	} else {
+		p.Skip()
+		frame.Start = frameBound(p)
+		if !expectKeyword(p, lex.KeywordAnd) {
+			return nil
+		}
+		end := frameBound(p)
+		frame.End = &end
+	}
+
+	switch {
+	case frame.Start.Kind == FrameUnboundedFollowing:
+		p.Errorf("a window frame cannot start with UNBOUNDED FOLLOWING")
+	case frame.End != nil && frame.End.Kind == FrameUnboundedPreceding:
+		p.Errorf("a window frame cannot end with UNBOUNDED PRECEDING")
+	}
+	return &frame
+}
+
+// frameBound parses the start or end of a window frame
+func frameBound(p *parse.Parser[Query]) FrameBound {
+	var bound FrameBound
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordUnbounded):
+		switch next := p.MustNext(); {
+		case lex.IsKeyword(next, lex.KeywordPreceding):
+			bound.Kind = FrameUnboundedPreceding
+		case lex.IsKeyword(next, lex.KeywordFollowing):
+			bound.Kind = FrameUnboundedFollowing
+		default:
+			p.Errorf("expected PRECEDING or FOLLOWING after UNBOUNDED, found [%s] instead", next.Val)
+		}
+	case lex.IsKeyword(next, lex.KeywordCurrent):
+		if row := p.MustNext(); !lex.IsIdentifier(row, "row") {
+			p.Errorf("expected ROW after CURRENT, found [%s] instead", row.Val)
+		}
+		bound.Kind = FrameCurrentRow
+	default:
+		p.Backup()
+		bound.Offset = expression(p)
+		switch next := p.MustNext(); {
+		case lex.IsKeyword(next, lex.KeywordPreceding):
+			bound.Kind = FramePreceding
+		case lex.IsKeyword(next, lex.KeywordFollowing):
+			bound.Kind = FrameFollowing
+		default:
+			p.Errorf("expected PRECEDING or FOLLOWING within window frame, found [%s] instead", next.Val)
+		}
+	}
+	return bound
+}
+
+// selectExpr parses the expression of a SELECT list item, which may also be a `*` or `table.*`
+func selectExpr(p *parse.Parser[Query]) Expr {
+	next := p.MustNext()
+	if isOperator(next, ColumnAsterisk) {
+		return starModifiers(p, Star{})
+	}
+
+	if (next.Typ == lex.ItemIdentifier || next.Typ == lex.ItemBacktickedIdentifier) && endsWithStar(p) {
+		table := strings.Join(namePath(p, next), ".")
+		p.Skip() // .
+		p.Skip() // *
+		return starModifiers(p, Star{Table: table})
+	}
+
+	p.Backup()
+	return expression(p)
+}
+
+// endsWithStar peeks along the dotted name that follows an identifier to report whether it ends
+// with a `.*`, without reading any of it
+func endsWithStar(p *parse.Parser[Query]) bool {
+	for n := 1; p.MustPeekN(n).Typ == lex.ItemDot; n += 2 {
+		switch part := p.MustPeekN(n + 1); {
+		case isOperator(part, ColumnAsterisk):
+			return true
+		case part.Typ != lex.ItemIdentifier && part.Typ != lex.ItemBacktickedIdentifier && part.Typ != lex.ItemKeyword:
+			return false
+		}
+	}
+	return false
+}
+
+// starModifiers parses the EXCEPT and REPLACE lists that may follow a `*` within the select list
+func starModifiers(p *parse.Parser[Query], star Star) Expr {
+	// a set operation EXCEPT is followed by DISTINCT or ALL, never a list
+	if next := p.MustNext(); lex.IsKeyword(next, lex.KeywordExcept) && p.MustPeek().Typ == lex.ItemLeftParen {
+		if star.Except = identifierList(p); star.Except == nil {
+			return nil
+		}
+	} else {
+		p.Backup()
+	}
+
+	if next := p.MustNext(); lex.IsIdentifier(next, "replace") && p.MustPeek().Typ == lex.ItemLeftParen {
+		p.Skip()
+		for !p.HasError() {
+			col := Column{Expr: expression(p)}
+			if lex.IsKeyword(p.MustPeek(), lex.KeywordAs) {
+				p.Skip()
+			}
+			switch alias := p.MustNext(); alias.Typ {
+			case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+				col.Alias = identifier(alias)
+			default:
+				return exprErrorf(p, "expected column name within REPLACE, found [%s] instead", alias.Val)
+			}
+			star.Replace = append(star.Replace, col)
+
+			if p.MustPeek().Typ != lex.ItemComma {
+				break
+			}
+			p.Skip()
+		}
+		if !expectItem(p, lex.ItemRightParen) {
+			return nil
+		}
+	} else {
+		p.Backup()
+	}
+
+	return star
+}
+
+// expressions parses a comma separated list of expressions
+func expressions(p *parse.Parser[Query]) []Expr {
+	var list []Expr
+	for !p.HasError() {
+		list = append(list, expression(p))
+		if p.MustPeek().Typ != lex.ItemComma {
+			return list
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// exprList parses a parenthesized, comma separated list of expressions
+func exprList(p *parse.Parser[Query]) []Expr {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+
+	var list []Expr
+	for !p.HasError() {
+		list = append(list, expression(p))
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return list
+		default:
+			exprErrorf(p, "expected ',' or ')' within list, found [%s] instead", next.Val)
+		}
+	}
+	return nil
+}
+
+// isQueryStart reports whether the next items begin a query, without reading them. A query may
+// begin with the parenthesized first operand of a set operation, as in
+// `(SELECT a FROM x) UNION ALL (SELECT a FROM y)`, which is told apart from a parenthesized
+// expression such as `(SELECT 1) + 1` by the item following its closing ')'.
+func isQueryStart(p *parse.Parser[Query]) bool {
+	return queryStartAt(p, 1)
+}
+
+// queryStartAt reports whether the items from the n-th one ahead begin a query
+func queryStartAt(p *parse.Parser[Query], n int) bool {
+	switch item := p.MustPeekN(n); {
+	case lex.IsKeyword(item, lex.KeywordSelect, lex.KeywordWith):
+		return true
+	case item.Typ != lex.ItemLeftParen || !queryStartAt(p, n+1):
+		return false
+	}
+
+	for depth := 0; ; n++ {
+		switch item := p.MustPeekN(n); item.Typ {
+		case lex.ItemLeftParen:
+			depth++
+		case lex.ItemRightParen:
+			if depth--; depth > 0 {
+				continue
+			}
+			next := p.MustPeekN(n + 1)
+			return lex.IsKeyword(next, lex.KeywordUnion, lex.KeywordIntersect, lex.KeywordExcept, lex.KeywordOrder, lex.KeywordLimit) ||
+				next.Typ == lex.ItemRightParen || next.Typ == lex.ItemStatementEnd || next.Typ == lex.ItemEOF
+		case lex.ItemEOF, lex.ItemError:
+			return false
+		}
+	}
+}
+
+// parenQuery parses a parenthesized subquery when the next items begin one. Nothing is read
+// and ok is false when they do not.
+func parenQuery(p *parse.Parser[Query]) (query *Query, ok bool) {
+	if p.MustPeek().Typ != lex.ItemLeftParen {
+		return nil, false
+	}
+	p.Skip()
+	if !isQueryStart(p) {
+		p.Backup()
+		return nil, false
+	}
+
+	query = subquery(p)
+	expectItem(p, lex.ItemRightParen)
+	return query, true
+}
+
+// expectItem reads the next item and records an error if it is not of the given type
+func expectItem(p *parse.Parser[Query], typ lex.ItemType) bool {
+	if next := p.MustNext(); next.Typ != typ {
+		p.Errorf("expected [%v], found [%s] instead", typ, next.Val)
+		return false
+	}
+	return true
+}
+
+// expectKeyword reads the next item and records an error if it is not the given keyword
+func expectKeyword(p *parse.Parser[Query], keyword string) bool {
+	if next := p.MustNext(); !lex.IsKeyword(next, keyword) {
+		p.Errorf("expected [%s], found [%s] instead", strings.ToUpper(keyword), next.Val)
+		return false
+	}
+	return true
+}
+
+func exprErrorf(p *parse.Parser[Query], format string, args ...interface{}) Expr {
+	p.Errorf(format, args...)
+	return nil
+}
@@ -13,9 +13,190 @@ func Parse(in string) (*Query, error) {
 		state = state(p)
 	}
 
+	// the state machine stops at the first item it does not recognize, which must end the statement
+	if !p.HasError() {
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemEOF, lex.ItemStatementEnd:
+		default:
+			p.Errorf("unexpected [%s] found at the end of the statement", next.Val)
+		}
+	}
+
 	return p.Get()
 }
 
+// subquery parses a query nested within the one p is parsing, using the same state machine
+// as Parse. The nested query ends at the first item it does not recognize, e.g. a ')'.
+func subquery(p *parse.Parser[Query]) *Query {
+	sub := p.Child()
+	for state := sqlStatement; state != nil && !sub.HasError(); {
+		state = state(sub)
+	}
+
+	query, err := sub.Get()
+	if err != nil {
+		p.Error(err)
+	}
+	return query
+}
+
+// ParseExpr parses an expression from the items of p, a parser for another kind of statement,
+// e.g. the default value of a column within CREATE TABLE. The expression ends at the first item
+// that cannot continue it, errors are reported through p.
+func ParseExpr[V any](p *parse.Parser[V]) (Expr, bool) {
+	sub := parse.Nested[Query](p)
+	expr := expression(sub)
+	if _, err := sub.Get(); err != nil {
+		p.Error(err)
+		return nil, false
+	}
+	return expr, true
+}
+
+func addCTE(p *parse.Parser[Query], cte CTE, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&cte) {
+		return p.Errorf("invalid common table expression found")
+	}
+	p.Result.With.CTEs = append(p.Result.With.CTEs, cte)
+	return next
+}
+
+// isCTE reports whether name refers to a common table expression defined by the query being
+// parsed, or by any of the queries it is nested within
+func isCTE(p *parse.Parser[Query], name string) bool {
+	for scope := p; scope != nil; scope = scope.Parent() {
+		if scope.Result.With == nil {
+			continue
+		}
+		for _, cte := range scope.Result.With.CTEs {
+			if strings.EqualFold(cte.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addSetOperand moves the SELECT parsed into p.Result, if any, to the operands of the set
+// operation being parsed. The WITH clause and comments belong to the whole statement.
+func addSetOperand(p *parse.Parser[Query]) {
+	if len(p.Result.Selects) == 0 {
+		return
+	}
+
+	core := *p.Result
+	core.Comments, core.With, core.operands = nil, nil, nil
+	*p.Result = Query{
+		Comments: p.Result.Comments,
+		Stmt:     p.Result.Stmt,
+		With:     p.Result.With,
+		operands: p.Result.operands,
+	}
+
+	if n := len(p.Result.operands); n > 0 && p.Result.operands[n-1].query == nil {
+		p.Result.operands[n-1].query = &core
+	} else {
+		p.Result.operands = append(p.Result.operands, setOperand{query: &core})
+	}
+}
+
+// endSetOperation combines the operands of a set operation once the last one has been parsed.
+// INTERSECT binds tighter than UNION and EXCEPT, operators of the same precedence are left
+// associative and a run of the same operator is combined into a single Compound.
+func endSetOperation(p *parse.Parser[Query]) {
+	addSetOperand(p)
+	operands := p.Result.operands
+	p.Result.operands = nil
+
+	if len(operands) == 1 { // a single parenthesized query
+		// it stands for the whole statement, unless its own ORDER BY or LIMIT would be replaced
+		// by those that follow it
+		query := operands[0].query
+		next := p.MustPeek()
+		if (query.OrderBy != nil || query.Limit != nil || query.Offset != nil) && lex.IsKeyword(next, lex.KeywordOrder, lex.KeywordLimit) {
+			p.Errorf("unsupported [%s] found after a parenthesized query with its own ORDER BY or LIMIT", next.Val)
+			return
+		}
+		query.Comments = append(p.Result.Comments, query.Comments...)
+		if query.With == nil {
+			query.With = p.Result.With
+		}
+		*p.Result = *query
+		return
+	}
+
+	combined := map[*Query]bool{}
+	combine := func(left *Query, operand setOperand) *Query {
+		if combined[left] && left.Compound.Op == operand.op && left.Compound.All == operand.all {
+			left.Compound.Queries = append(left.Compound.Queries, operand.query)
+			return left
+		}
+		query := &Query{
+			Stmt:     StatementSelect,
+			Compound: &Compound{Op: operand.op, All: operand.all, Queries: []*Query{left, operand.query}},
+		}
+		combined[query] = true
+		return query
+	}
+
+	// combine the INTERSECT operators first, leaving the operands of UNION and EXCEPT
+	terms := []setOperand{operands[0]}
+	for _, operand := range operands[1:] {
+		if operand.op == SetIntersect {
+			last := &terms[len(terms)-1]
+			last.query = combine(last.query, operand)
+		} else {
+			terms = append(terms, operand)
+		}
+	}
+
+	result := terms[0].query
+	for _, term := range terms[1:] {
+		result = combine(result, term)
+	}
+	p.Result.Compound = result.Compound
+}
+
+func setInsert(p *parse.Parser[Query], insert Insert, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&insert) {
+		return p.Errorf("invalid INSERT statement found for table [%s]", insert.Table.Name)
+	}
+	p.Result.Insert = &insert
+	return next
+}
+
+func setUpdate(p *parse.Parser[Query], update Update, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&update) {
+		return p.Errorf("invalid UPDATE statement found for table [%s]", update.Table.Name)
+	}
+	p.Result.Update = &update
+	return next
+}
+
+func setDelete(p *parse.Parser[Query], del Delete, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&del) {
+		return p.Errorf("invalid DELETE statement found")
+	}
+	p.Result.Delete = &del
+	return next
+}
+
+func addMergeClause(p *parse.Parser[Query], clause MergeClause, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&clause) {
+		return p.Errorf("invalid WHEN %s THEN %s clause found within MERGE", clause.Match, clause.Action)
+	}
+	p.Result.Merge.Clauses = append(p.Result.Merge.Clauses, clause)
+	return next
+}
+
+func addWindow(p *parse.Parser[Query], window NamedWindow, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&window) {
+		return p.Errorf("invalid named window found")
+	}
+	p.Result.Window = append(p.Result.Window, window)
+	return next
+}
+
 func addSelect(p *parse.Parser[Query], column Column, next parse.StateFn[Query]) parse.StateFn[Query] {
 	if !parse.Validate(&column) {
 		return p.Errorf("invalid select column found")
@@ -32,6 +213,16 @@ func addFrom(p *parse.Parser[Query], from Table, next parse.StateFn[Query]) pars
 	return next
 }
 
+// addJoin joins a table onto the FROM item it follows, the last table of the FROM clause
+func addJoin(p *parse.Parser[Query], join Join, next parse.StateFn[Query]) parse.StateFn[Query] {
+	if !parse.Validate(&join) {
+		return p.Errorf("invalid join found for table [%s]", join.Table.Name)
+	}
+	from := &p.Result.Froms[len(p.Result.Froms)-1]
+	from.Joins = append(from.Joins, join)
+	return next
+}
+
 func addComment(p *parse.Parser[Query], comment string, next parse.StateFn[Query]) parse.StateFn[Query] {
 	if !parse.Validate(&comment) {
 		return p.Errorf("invalid comment found")
@@ -40,13 +231,13 @@ func addComment(p *parse.Parser[Query], comment string, next parse.StateFn[Query
 	return next
 }
 
-func isKeyword(item lex.Item, keywords ...string) bool {
-	if item.Typ != lex.ItemKeyword {
+func isOperator(item lex.Item, operators ...string) bool {
+	if item.Typ != lex.ItemOperator {
 		return false
 	}
 
-	for _, k := range keywords {
-		if strings.EqualFold(k, item.Val) {
+	for _, o := range operators {
+		if o == item.Val {
 			return true
 		}
 	}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ryan-holcombe/sqlparser/types"
 )
 
 func TestParse_Comments(t *testing.T) {
@@ -39,7 +41,8 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Column: "*"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: Star{}}, query.Selects[0])
+		assert.Equal(t, ColumnAsterisk, query.Selects[0].Column())
 	})
 
 	t.Run("alias with AS", func(t *testing.T) {
@@ -48,7 +51,7 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Column: "name", Alias: "user_name"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: ColumnRef{Column: "name"}, Alias: "user_name"}, query.Selects[0])
 	})
 
 	t.Run("alias without AS", func(t *testing.T) {
@@ -57,16 +60,17 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Column: "name", Alias: "user_name"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: ColumnRef{Column: "name"}, Alias: "user_name"}, query.Selects[0])
 	})
 
+	// backticked names are stored without their backticks, the same as an unquoted name
 	t.Run("backticked column", func(t *testing.T) {
 		input := "SELECT `name` AS user_name FROM users;"
 		query, err := Parse(input)
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Column: "`name`", Alias: "user_name"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: ColumnRef{Column: "name"}, Alias: "user_name"}, query.Selects[0])
 	})
 
 	t.Run("table and column", func(t *testing.T) {
@@ -75,7 +79,9 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Table: "user", Column: "name"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: ColumnRef{Table: "user", Column: "name"}}, query.Selects[0])
+		assert.Equal(t, "user", query.Selects[0].Table())
+		assert.Equal(t, "name", query.Selects[0].Column())
 	})
 
 	t.Run("backticked table and column", func(t *testing.T) {
@@ -84,7 +90,7 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Table: "`user`", Column: "`name`"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: ColumnRef{Table: "user", Column: "name"}}, query.Selects[0])
 	})
 
 	t.Run("backticked table and asterisk", func(t *testing.T) {
@@ -93,7 +99,9 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 1)
-		assert.Equal(t, Column{Table: "`user`", Column: "*"}, query.Selects[0])
+		assert.Equal(t, Column{Expr: Star{Table: "user"}}, query.Selects[0])
+		assert.Equal(t, "user", query.Selects[0].Table())
+		assert.Equal(t, ColumnAsterisk, query.Selects[0].Column())
 	})
 
 	t.Run("multiple selects", func(t *testing.T) {
@@ -102,9 +110,40 @@ func TestParse_SelectColumns(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, StatementSelect, query.Stmt)
 		assert.Len(t, query.Selects, 3)
-		assert.Equal(t, Column{Table: "user", Column: "id"}, query.Selects[0])
-		assert.Equal(t, Column{Table: "user", Column: "*"}, query.Selects[1])
-		assert.Equal(t, Column{Column: "name"}, query.Selects[2])
+		assert.Equal(t, Column{Expr: ColumnRef{Table: "user", Column: "id"}}, query.Selects[0])
+		assert.Equal(t, Column{Expr: Star{Table: "user"}}, query.Selects[1])
+		assert.Equal(t, Column{Expr: ColumnRef{Column: "name"}}, query.Selects[2])
+	})
+
+	t.Run("function call", func(t *testing.T) {
+		input := `SELECT COUNT(*) total, MAX(age) AS oldest, NOW() FROM users;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Column{
+			{Expr: FuncCall{Name: "COUNT", Args: []Expr{Star{}}}, Alias: "total"},
+			{Expr: FuncCall{Name: "MAX", Args: []Expr{ColumnRef{Column: "age"}}}, Alias: "oldest"},
+			{Expr: FuncCall{Name: "NOW"}},
+		}, query.Selects)
+		assert.Empty(t, query.Selects[0].Table())
+		assert.Empty(t, query.Selects[0].Column())
+	})
+
+	t.Run("arithmetic and literals", func(t *testing.T) {
+		input := `SELECT price * quantity AS total, 'USD' currency, 1 FROM orders;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Column{
+			{Expr: Binary{Op: "*", Left: ColumnRef{Column: "price"}, Right: ColumnRef{Column: "quantity"}}, Alias: "total"},
+			{Expr: Literal{Kind: LiteralString, Val: "'USD'"}, Alias: "currency"},
+			{Expr: Literal{Kind: LiteralNumber, Val: "1"}},
+		}, query.Selects)
+	})
+
+	t.Run("without from", func(t *testing.T) {
+		query, err := Parse(`SELECT 1 + 1;`)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects, 1)
+		assert.Empty(t, query.Froms)
 	})
 
 	t.Run("missing comma between selects", func(t *testing.T) {
@@ -149,3 +188,1465 @@ func TestParse_Froms(t *testing.T) {
 		assert.Equal(t, Table{Name: "people"}, query.Froms[1])
 	})
 }
+
+func TestParse_Where(t *testing.T) {
+	t.Run("comparison", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE name = 'Bob';`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Froms, 1)
+		assert.Equal(t, Binary{Op: "=", Left: ColumnRef{Column: "name"}, Right: Literal{Kind: LiteralString, Val: "'Bob'"}}, query.Where)
+	})
+
+	t.Run("and binds tighter than or", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE a = 1 OR b = 2 AND NOT c = 3`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Binary{
+			Op:   "OR",
+			Left: Binary{Op: "=", Left: ColumnRef{Column: "a"}, Right: Literal{Kind: LiteralNumber, Val: "1"}},
+			Right: Binary{
+				Op:    "AND",
+				Left:  Binary{Op: "=", Left: ColumnRef{Column: "b"}, Right: Literal{Kind: LiteralNumber, Val: "2"}},
+				Right: Unary{Op: "NOT", Expr: Binary{Op: "=", Left: ColumnRef{Column: "c"}, Right: Literal{Kind: LiteralNumber, Val: "3"}}},
+			},
+		}, query.Where)
+	})
+
+	t.Run("arithmetic precedence", func(t *testing.T) {
+		input := `SELECT * FROM users u WHERE u.age * 2 + 1 > -5`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Binary{
+			Op: ">",
+			Left: Binary{
+				Op:    "+",
+				Left:  Binary{Op: "*", Left: ColumnRef{Table: "u", Column: "age"}, Right: Literal{Kind: LiteralNumber, Val: "2"}},
+				Right: Literal{Kind: LiteralNumber, Val: "1"},
+			},
+			Right: Unary{Op: "-", Expr: Literal{Kind: LiteralNumber, Val: "5"}},
+		}, query.Where)
+	})
+
+	t.Run("parenthesized group", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE (a OR b) AND c`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Binary{
+			Op:    "AND",
+			Left:  Paren{Expr: Binary{Op: "OR", Left: ColumnRef{Column: "a"}, Right: ColumnRef{Column: "b"}}},
+			Right: ColumnRef{Column: "c"},
+		}, query.Where)
+	})
+
+	t.Run("is null", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM users WHERE deleted_at IS NULL`)
+		assert.NoError(t, err)
+		assert.Equal(t, IsNull{Expr: ColumnRef{Column: "deleted_at"}}, query.Where)
+
+		query, err = Parse(`SELECT * FROM users WHERE deleted_at IS NOT NULL`)
+		assert.NoError(t, err)
+		assert.Equal(t, IsNull{Expr: ColumnRef{Column: "deleted_at"}, Not: true}, query.Where)
+	})
+
+	t.Run("between", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE age NOT BETWEEN 18 AND 65 AND active = TRUE`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Binary{
+			Op:    "AND",
+			Left:  Between{Expr: ColumnRef{Column: "age"}, Low: Literal{Kind: LiteralNumber, Val: "18"}, High: Literal{Kind: LiteralNumber, Val: "65"}, Not: true},
+			Right: Binary{Op: "=", Left: ColumnRef{Column: "active"}, Right: Literal{Kind: LiteralBool, Val: "TRUE"}},
+		}, query.Where)
+	})
+
+	t.Run("like", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE name LIKE 'B%'`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Like{Expr: ColumnRef{Column: "name"}, Pattern: Literal{Kind: LiteralString, Val: "'B%'"}}, query.Where)
+	})
+
+	t.Run("in list", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE id NOT IN (1, 2, 3)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, In{
+			Expr: ColumnRef{Column: "id"},
+			List: []Expr{Literal{Kind: LiteralNumber, Val: "1"}, Literal{Kind: LiteralNumber, Val: "2"}, Literal{Kind: LiteralNumber, Val: "3"}},
+			Not:  true,
+		}, query.Where)
+	})
+
+	t.Run("missing right paren", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users WHERE (a = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("trailing tokens", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users WHERE a = 1 b`)
+		assert.Error(t, err)
+	})
+
+	t.Run("not without predicate", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users WHERE a NOT 1`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Joins(t *testing.T) {
+	t.Run("inner join with on", func(t *testing.T) {
+		input := `SELECT * FROM users u JOIN addresses a ON u.id = a.user_id WHERE a.city = 'Denver';`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{
+			Name:  "users",
+			Alias: "u",
+			Joins: []Join{{
+				Kind:  JoinInner,
+				Table: Table{Name: "addresses", Alias: "a"},
+				On:    Binary{Op: "=", Left: ColumnRef{Table: "u", Column: "id"}, Right: ColumnRef{Table: "a", Column: "user_id"}},
+			}},
+		}}, query.Froms)
+		assert.NotNil(t, query.Where)
+	})
+
+	t.Run("outer joins", func(t *testing.T) {
+		input := `SELECT * FROM users
+			LEFT OUTER JOIN addresses ON users.id = addresses.user_id
+			RIGHT JOIN orders AS o USING (user_id)
+			FULL JOIN payments p USING (user_id, order_id)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Froms[0].Joins, 3)
+		assert.Equal(t, JoinLeft, query.Froms[0].Joins[0].Kind)
+		assert.Equal(t, Table{Name: "addresses"}, query.Froms[0].Joins[0].Table)
+		assert.Equal(t, Join{Kind: JoinRight, Table: Table{Name: "orders", Alias: "o"}, Using: []string{"user_id"}}, query.Froms[0].Joins[1])
+		assert.Equal(t, Join{Kind: JoinFull, Table: Table{Name: "payments", Alias: "p"}, Using: []string{"user_id", "order_id"}}, query.Froms[0].Joins[2])
+	})
+
+	t.Run("cross and natural joins", func(t *testing.T) {
+		input := `SELECT * FROM users CROSS JOIN roles NATURAL LEFT JOIN permissions`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Join{
+			{Kind: JoinCross, Table: Table{Name: "roles"}},
+			{Kind: JoinLeft, Natural: true, Table: Table{Name: "permissions"}},
+		}, query.Froms[0].Joins)
+	})
+
+	t.Run("join followed by another table", func(t *testing.T) {
+		input := `SELECT * FROM users INNER JOIN roles ON users.role_id = roles.id, teams`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Froms, 2)
+		assert.Equal(t, "users", query.Froms[0].Name)
+		assert.Len(t, query.Froms[0].Joins, 1)
+		assert.Equal(t, Table{Name: "teams"}, query.Froms[1])
+	})
+
+	t.Run("missing join condition", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users JOIN roles`)
+		assert.Error(t, err)
+	})
+
+	t.Run("cross join with condition", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users CROSS JOIN roles ON users.id = roles.id`)
+		assert.Error(t, err)
+	})
+
+	t.Run("outer without side", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users OUTER JOIN roles ON users.id = roles.id`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Clauses(t *testing.T) {
+	t.Run("group by and having", func(t *testing.T) {
+		input := `SELECT * FROM orders WHERE status = 'paid' GROUP BY customer_id, region HAVING total > 100`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.NotNil(t, query.Where)
+		assert.Equal(t, []Grouping{
+			{Kind: GroupingExpr, Expr: ColumnRef{Column: "customer_id"}},
+			{Kind: GroupingExpr, Expr: ColumnRef{Column: "region"}},
+		}, query.GroupBy)
+		assert.Equal(t, Binary{Op: ">", Left: ColumnRef{Column: "total"}, Right: Literal{Kind: LiteralNumber, Val: "100"}}, query.Having)
+	})
+
+	t.Run("rollup and cube", func(t *testing.T) {
+		input := `SELECT * FROM orders GROUP BY ROLLUP (year, (quarter, month)), CUBE (region)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Grouping{
+			{Kind: GroupingRollup, Items: []Grouping{
+				{Kind: GroupingExpr, Expr: ColumnRef{Column: "year"}},
+				{Kind: GroupingSet, Items: []Grouping{
+					{Kind: GroupingExpr, Expr: ColumnRef{Column: "quarter"}},
+					{Kind: GroupingExpr, Expr: ColumnRef{Column: "month"}},
+				}},
+			}},
+			{Kind: GroupingCube, Items: []Grouping{
+				{Kind: GroupingExpr, Expr: ColumnRef{Column: "region"}},
+			}},
+		}, query.GroupBy)
+	})
+
+	t.Run("grouping sets", func(t *testing.T) {
+		input := `SELECT * FROM orders GROUP BY GROUPING SETS ((region, year), ROLLUP (month), ())`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.GroupBy, 1)
+		assert.Equal(t, GroupingSets, query.GroupBy[0].Kind)
+		assert.Len(t, query.GroupBy[0].Items, 3)
+		assert.Equal(t, GroupingSet, query.GroupBy[0].Items[0].Kind)
+		assert.Len(t, query.GroupBy[0].Items[0].Items, 2)
+		assert.Equal(t, GroupingRollup, query.GroupBy[0].Items[1].Kind)
+		assert.Equal(t, Grouping{Kind: GroupingSet}, query.GroupBy[0].Items[2])
+	})
+
+	t.Run("parenthesized expression in rollup", func(t *testing.T) {
+		input := `SELECT * FROM orders GROUP BY ROLLUP((a+b)*2, c)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Grouping{
+			{Kind: GroupingRollup, Items: []Grouping{
+				{Kind: GroupingExpr, Expr: Binary{
+					Op:    "*",
+					Left:  Paren{Expr: Binary{Op: "+", Left: ColumnRef{Column: "a"}, Right: ColumnRef{Column: "b"}}},
+					Right: Literal{Kind: LiteralNumber, Val: "2"},
+				}},
+				{Kind: GroupingExpr, Expr: ColumnRef{Column: "c"}},
+			}},
+		}, query.GroupBy)
+	})
+
+	t.Run("order by", func(t *testing.T) {
+		input := `SELECT * FROM users ORDER BY last_name, age DESC NULLS LAST, id ASC`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []OrderItem{
+			{Expr: ColumnRef{Column: "last_name"}},
+			{Expr: ColumnRef{Column: "age"}, Direction: SortDesc, Nulls: NullsLast},
+			{Expr: ColumnRef{Column: "id"}, Direction: SortAsc},
+		}, query.OrderBy)
+	})
+
+	t.Run("limit and offset", func(t *testing.T) {
+		input := `SELECT * FROM users ORDER BY id LIMIT 10 OFFSET 5 * 2;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "10"}, query.Limit)
+		assert.Equal(t, Binary{Op: "*", Left: Literal{Kind: LiteralNumber, Val: "5"}, Right: Literal{Kind: LiteralNumber, Val: "2"}}, query.Offset)
+	})
+
+	t.Run("clauses out of order", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users ORDER BY id WHERE id = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate clause", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users WHERE id = 1 WHERE id = 2`)
+		assert.Error(t, err)
+	})
+
+	t.Run("group without by", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users GROUP id`)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid nulls order", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users ORDER BY id NULLS MIDDLE`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Subqueries(t *testing.T) {
+	t.Run("derived table", func(t *testing.T) {
+		input := `SELECT t.id FROM (SELECT id FROM users WHERE active = TRUE) AS t;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Froms, 1)
+		assert.Equal(t, "t", query.Froms[0].Alias)
+		assert.Empty(t, query.Froms[0].Name)
+		sub := query.Froms[0].Subquery
+		assert.NotNil(t, sub)
+		assert.Equal(t, StatementSelect, sub.Stmt)
+		assert.Equal(t, []Column{{Expr: ColumnRef{Column: "id"}}}, sub.Selects)
+		assert.Equal(t, []Table{{Name: "users"}}, sub.Froms)
+		assert.NotNil(t, sub.Where)
+	})
+
+	t.Run("joined derived table", func(t *testing.T) {
+		input := `SELECT * FROM users u LEFT JOIN (SELECT user_id FROM orders) o ON u.id = o.user_id`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Froms[0].Joins, 1)
+		assert.Equal(t, "o", query.Froms[0].Joins[0].Table.Alias)
+		assert.NotNil(t, query.Froms[0].Joins[0].Table.Subquery)
+	})
+
+	t.Run("scalar subquery", func(t *testing.T) {
+		input := `SELECT name, (SELECT COUNT(*) FROM orders o WHERE o.user_id = u.id) + 1 AS orders FROM users u`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects, 2)
+		assert.Equal(t, "orders", query.Selects[1].Alias)
+		sum, ok := query.Selects[1].Expr.(Binary)
+		assert.True(t, ok)
+		sub, ok := sum.Left.(Subquery)
+		assert.True(t, ok)
+		assert.Equal(t, []Table{{Name: "orders", Alias: "o"}}, sub.Query.Froms)
+	})
+
+	t.Run("exists", func(t *testing.T) {
+		input := `SELECT * FROM users u WHERE NOT EXISTS (SELECT 1 FROM bans b WHERE b.user_id = u.id)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		not, ok := query.Where.(Unary)
+		assert.True(t, ok)
+		exists, ok := not.Expr.(Exists)
+		assert.True(t, ok)
+		assert.Equal(t, []Table{{Name: "bans", Alias: "b"}}, exists.Query.Froms)
+	})
+
+	t.Run("in subquery", func(t *testing.T) {
+		input := `SELECT * FROM users WHERE id IN (SELECT user_id FROM orders LIMIT 5) AND age > 21`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		and, ok := query.Where.(Binary)
+		assert.True(t, ok)
+		in, ok := and.Left.(In)
+		assert.True(t, ok)
+		assert.Nil(t, in.List)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "5"}, in.Query.Limit)
+	})
+
+	t.Run("deeply nested", func(t *testing.T) {
+		input := `SELECT * FROM (
+			SELECT * FROM (
+				SELECT * FROM (
+					SELECT id FROM events WHERE id IN (SELECT id FROM archived)
+				) a
+			) b
+		) c WHERE c.id > 10`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.NotNil(t, query.Where)
+		b := query.Froms[0].Subquery
+		a := b.Froms[0].Subquery
+		events := a.Froms[0].Subquery
+		assert.Equal(t, []Table{{Name: "events"}}, events.Froms)
+		assert.IsType(t, In{}, events.Where)
+	})
+
+	t.Run("unterminated subquery", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM (SELECT id FROM users t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("exists without subquery", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users WHERE EXISTS (1)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unbalanced parenthesis", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM users)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_With(t *testing.T) {
+	t.Run("multiple ctes", func(t *testing.T) {
+		input := `WITH active AS (SELECT * FROM users WHERE active = TRUE),
+			totals (user_id, total) AS (SELECT user_id, SUM(amount) FROM orders GROUP BY user_id)
+		SELECT a.name, t.total FROM active a JOIN totals t ON a.id = t.user_id JOIN accounts ac ON ac.id = a.id`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementSelect, query.Stmt)
+		assert.NotNil(t, query.With)
+		assert.False(t, query.With.Recursive)
+		assert.Len(t, query.With.CTEs, 2)
+		assert.Equal(t, "active", query.With.CTEs[0].Name)
+		assert.Nil(t, query.With.CTEs[0].Columns)
+		assert.Equal(t, []Table{{Name: "users"}}, query.With.CTEs[0].Query.Froms)
+		assert.Equal(t, "totals", query.With.CTEs[1].Name)
+		assert.Equal(t, []string{"user_id", "total"}, query.With.CTEs[1].Columns)
+		assert.Len(t, query.With.CTEs[1].Query.GroupBy, 1)
+
+		assert.Len(t, query.Froms, 1)
+		assert.Equal(t, "active", query.Froms[0].Name)
+		assert.True(t, query.Froms[0].CTE)
+		assert.Equal(t, Table{Name: "totals", Alias: "t", CTE: true}, query.Froms[0].Joins[0].Table)
+		assert.Equal(t, Table{Name: "accounts", Alias: "ac"}, query.Froms[0].Joins[1].Table)
+	})
+
+	t.Run("earlier cte referenced by later cte and subquery", func(t *testing.T) {
+		input := `WITH a AS (SELECT 1 AS x), b AS (SELECT x FROM a)
+		SELECT * FROM b WHERE x IN (SELECT x FROM a)`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{Name: "a", CTE: true}}, query.With.CTEs[1].Query.Froms)
+		assert.Equal(t, []Table{{Name: "b", CTE: true}}, query.Froms)
+		in := query.Where.(In)
+		assert.Equal(t, []Table{{Name: "a", CTE: true}}, in.Query.Froms)
+	})
+
+	t.Run("cte is not in scope within itself unless recursive", func(t *testing.T) {
+		query, err := Parse(`WITH a AS (SELECT * FROM a) SELECT * FROM a`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{Name: "a"}}, query.With.CTEs[0].Query.Froms)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		input := `WITH RECURSIVE tree AS (SELECT id, parent_id FROM nodes n JOIN tree t ON n.parent_id = t.id)
+		SELECT * FROM tree`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.True(t, query.With.Recursive)
+		assert.Equal(t, "nodes", query.With.CTEs[0].Query.Froms[0].Name)
+		assert.Equal(t, Table{Name: "tree", Alias: "t", CTE: true}, query.With.CTEs[0].Query.Froms[0].Joins[0].Table)
+		assert.Equal(t, []Table{{Name: "tree", CTE: true}}, query.Froms)
+	})
+
+	t.Run("nested with", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM (WITH a AS (SELECT 1) SELECT * FROM a) sub, a`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{Name: "a", CTE: true}}, query.Froms[0].Subquery.Froms)
+		assert.Equal(t, Table{Name: "a"}, query.Froms[1])
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		_, err := Parse(`WITH a AS (SELECT 1)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing as", func(t *testing.T) {
+		_, err := Parse(`WITH a (SELECT 1) SELECT * FROM a`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_SetOperations(t *testing.T) {
+	t.Run("union all", func(t *testing.T) {
+		input := `SELECT id FROM users UNION ALL SELECT id FROM admins UNION ALL SELECT id FROM guests ORDER BY id LIMIT 10`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementSelect, query.Stmt)
+		assert.Empty(t, query.Selects)
+		assert.NotNil(t, query.Compound)
+		assert.Equal(t, SetUnion, query.Compound.Op)
+		assert.True(t, query.Compound.All)
+		assert.Len(t, query.Compound.Queries, 3)
+		assert.Equal(t, []Table{{Name: "users"}}, query.Compound.Queries[0].Froms)
+		assert.Equal(t, []Table{{Name: "admins"}}, query.Compound.Queries[1].Froms)
+		assert.Equal(t, []Table{{Name: "guests"}}, query.Compound.Queries[2].Froms)
+		assert.Nil(t, query.Compound.Queries[2].OrderBy)
+		assert.Len(t, query.OrderBy, 1)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "10"}, query.Limit)
+	})
+
+	t.Run("intersect binds tighter than union", func(t *testing.T) {
+		input := `SELECT a FROM t1 UNION DISTINCT SELECT a FROM t2 INTERSECT DISTINCT SELECT a FROM t3 EXCEPT DISTINCT SELECT a FROM t4`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+
+		// (t1 UNION (t2 INTERSECT t3)) EXCEPT t4
+		except := query.Compound
+		assert.Equal(t, SetExcept, except.Op)
+		assert.False(t, except.All)
+		assert.Len(t, except.Queries, 2)
+		assert.Equal(t, []Table{{Name: "t4"}}, except.Queries[1].Froms)
+
+		union := except.Queries[0].Compound
+		assert.Equal(t, SetUnion, union.Op)
+		assert.Len(t, union.Queries, 2)
+		assert.Equal(t, []Table{{Name: "t1"}}, union.Queries[0].Froms)
+
+		intersect := union.Queries[1].Compound
+		assert.Equal(t, SetIntersect, intersect.Op)
+		assert.Equal(t, []Table{{Name: "t2"}}, intersect.Queries[0].Froms)
+		assert.Equal(t, []Table{{Name: "t3"}}, intersect.Queries[1].Froms)
+	})
+
+	t.Run("different modifiers are not combined", func(t *testing.T) {
+		query, err := Parse(`SELECT 1 UNION ALL SELECT 2 UNION DISTINCT SELECT 3`)
+		assert.NoError(t, err)
+		assert.False(t, query.Compound.All)
+		assert.Len(t, query.Compound.Queries, 2)
+		assert.True(t, query.Compound.Queries[0].Compound.All)
+	})
+
+	t.Run("parenthesized operands", func(t *testing.T) {
+		input := `(SELECT id FROM a ORDER BY id LIMIT 1) UNION ALL (SELECT id FROM b UNION ALL SELECT id FROM c) LIMIT 5`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Compound.Queries, 2)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "1"}, query.Compound.Queries[0].Limit)
+		assert.Equal(t, SetUnion, query.Compound.Queries[1].Compound.Op)
+		assert.Len(t, query.Compound.Queries[1].Compound.Queries, 2)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "5"}, query.Limit)
+	})
+
+	t.Run("with clause applies to the whole statement", func(t *testing.T) {
+		input := `-- all ids
+WITH x AS (SELECT 1 AS id) SELECT id FROM x UNION ALL SELECT id FROM x`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Comments, 1)
+		assert.NotNil(t, query.With)
+		assert.Nil(t, query.Compound.Queries[0].With)
+		assert.Equal(t, []Table{{Name: "x", CTE: true}}, query.Compound.Queries[1].Froms)
+	})
+
+	t.Run("within a subquery", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM users WHERE id IN (SELECT id FROM a EXCEPT DISTINCT SELECT id FROM b)`)
+		assert.NoError(t, err)
+		in := query.Where.(In)
+		assert.Equal(t, SetExcept, in.Query.Compound.Op)
+	})
+
+	t.Run("parenthesized first operand within a subquery", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM ((SELECT a FROM x) UNION ALL (SELECT a FROM y)) t`)
+		assert.NoError(t, err)
+		assert.Equal(t, "t", query.Froms[0].Alias)
+		assert.Equal(t, SetUnion, query.Froms[0].Subquery.Compound.Op)
+		assert.Len(t, query.Froms[0].Subquery.Compound.Queries, 2)
+
+		query, err = Parse(`SELECT * FROM t WHERE a IN ((SELECT 1) UNION ALL (SELECT 2))`)
+		assert.NoError(t, err)
+		assert.Len(t, query.Where.(In).Query.Compound.Queries, 2)
+
+		query, err = Parse(`SELECT ((SELECT 1) UNION ALL (SELECT 2) LIMIT 1) AS one`)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects[0].Expr.(Subquery).Query.Compound.Queries, 2)
+
+		query, err = Parse(`INSERT INTO t ((SELECT a FROM x) UNION ALL (SELECT a FROM y))`)
+		assert.NoError(t, err)
+		assert.Nil(t, query.Insert.Columns)
+		assert.Len(t, query.Insert.Query.Compound.Queries, 2)
+	})
+
+	t.Run("parenthesized subquery within an expression", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM t WHERE ((SELECT MAX(a) FROM x) + 1) > b`)
+		assert.NoError(t, err)
+		sum := query.Where.(Binary).Left.(Paren).Expr.(Binary)
+		assert.IsType(t, Subquery{}, sum.Left)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "1"}, sum.Right)
+	})
+
+	t.Run("single parenthesized query", func(t *testing.T) {
+		query, err := Parse(`(SELECT id FROM users)`)
+		assert.NoError(t, err)
+		assert.Nil(t, query.Compound)
+		assert.Equal(t, []Table{{Name: "users"}}, query.Froms)
+	})
+
+	t.Run("single parenthesized query with its own limit", func(t *testing.T) {
+		query, err := Parse(`(SELECT a FROM t ORDER BY a LIMIT 1)`)
+		assert.NoError(t, err)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "1"}, query.Limit)
+
+		query, err = Parse(`(SELECT a FROM t) ORDER BY a LIMIT 5`)
+		assert.NoError(t, err)
+		assert.Len(t, query.OrderBy, 1)
+		assert.Equal(t, Literal{Kind: LiteralNumber, Val: "5"}, query.Limit)
+
+		for _, input := range []string{
+			`(SELECT a FROM t ORDER BY a LIMIT 1) LIMIT 5`,
+			`(SELECT a FROM t LIMIT 1) ORDER BY a`,
+			`SELECT * FROM t WHERE a IN ((SELECT a FROM x ORDER BY a) LIMIT 1)`,
+		} {
+			_, err := Parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+
+	t.Run("missing operand", func(t *testing.T) {
+		_, err := Parse(`SELECT id FROM users UNION ALL`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Insert(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		input := `INSERT INTO users (id, name, created_at) VALUES (1, 'Bob', DEFAULT), (2, 'Alice', NOW());`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementInsert, query.Stmt)
+		assert.Equal(t, &Insert{
+			Table:   Table{Name: "users"},
+			Columns: []string{"id", "name", "created_at"},
+			Rows: [][]Expr{
+				{Literal{Kind: LiteralNumber, Val: "1"}, Literal{Kind: LiteralString, Val: "'Bob'"}, Default{}},
+				{Literal{Kind: LiteralNumber, Val: "2"}, Literal{Kind: LiteralString, Val: "'Alice'"}, FuncCall{Name: "NOW"}},
+			},
+		}, query.Insert)
+	})
+
+	t.Run("without into or columns", func(t *testing.T) {
+		query, err := Parse(`INSERT users VALUES (1, 'Bob')`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "users"}, query.Insert.Table)
+		assert.Nil(t, query.Insert.Columns)
+		assert.Len(t, query.Insert.Rows, 1)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		input := `-- copy the active users
+INSERT INTO archive (id, name) SELECT id, name FROM users WHERE active = FALSE`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementInsert, query.Stmt)
+		assert.Len(t, query.Comments, 1)
+		assert.Equal(t, []string{"id", "name"}, query.Insert.Columns)
+		assert.Nil(t, query.Insert.Rows)
+		assert.Equal(t, StatementSelect, query.Insert.Query.Stmt)
+		assert.Equal(t, []Table{{Name: "users"}}, query.Insert.Query.Froms)
+		assert.NotNil(t, query.Insert.Query.Where)
+	})
+
+	t.Run("parenthesized query", func(t *testing.T) {
+		query, err := Parse(`INSERT INTO archive (SELECT * FROM users) UNION ALL (SELECT * FROM admins)`)
+		assert.NoError(t, err)
+		assert.Nil(t, query.Insert.Columns)
+		assert.Equal(t, SetUnion, query.Insert.Query.Compound.Op)
+	})
+
+	t.Run("with query", func(t *testing.T) {
+		query, err := Parse(`INSERT INTO archive WITH old AS (SELECT * FROM users) SELECT * FROM old`)
+		assert.NoError(t, err)
+		assert.NotNil(t, query.Insert.Query.With)
+		assert.Equal(t, []Table{{Name: "old", CTE: true}}, query.Insert.Query.Froms)
+	})
+
+	t.Run("mismatched values", func(t *testing.T) {
+		_, err := Parse(`INSERT INTO users (id, name) VALUES (1, 'Bob'), (2)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		_, err := Parse(`INSERT INTO users (id, name)`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing table", func(t *testing.T) {
+		_, err := Parse(`INSERT INTO VALUES (1)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Update(t *testing.T) {
+	t.Run("with where", func(t *testing.T) {
+		input := `UPDATE users u SET u.name = 'Bob', age = age + 1, updated_at = DEFAULT WHERE u.id = 5;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementUpdate, query.Stmt)
+		assert.Equal(t, &Update{
+			Table: Table{Name: "users", Alias: "u"},
+			Assignments: []Assignment{
+				{Column: ColumnRef{Table: "u", Column: "name"}, Value: Literal{Kind: LiteralString, Val: "'Bob'"}},
+				{Column: ColumnRef{Column: "age"}, Value: Binary{Op: "+", Left: ColumnRef{Column: "age"}, Right: Literal{Kind: LiteralNumber, Val: "1"}}},
+				{Column: ColumnRef{Column: "updated_at"}, Value: Default{}},
+			},
+			Where: Binary{Op: "=", Left: ColumnRef{Table: "u", Column: "id"}, Right: Literal{Kind: LiteralNumber, Val: "5"}},
+		}, query.Update)
+	})
+
+	t.Run("without where", func(t *testing.T) {
+		query, err := Parse(`UPDATE users AS u SET active = FALSE`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "users", Alias: "u"}, query.Update.Table)
+		assert.Len(t, query.Update.Assignments, 1)
+		assert.Nil(t, query.Update.Where)
+	})
+
+	t.Run("subquery value", func(t *testing.T) {
+		query, err := Parse(`UPDATE users SET total = (SELECT SUM(amount) FROM orders WHERE orders.user_id = users.id)`)
+		assert.NoError(t, err)
+		assert.IsType(t, Subquery{}, query.Update.Assignments[0].Value)
+	})
+
+	t.Run("missing set", func(t *testing.T) {
+		_, err := Parse(`UPDATE users WHERE id = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing assignment operator", func(t *testing.T) {
+		_, err := Parse(`UPDATE users SET name 'Bob'`)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty set", func(t *testing.T) {
+		_, err := Parse(`UPDATE users SET WHERE id = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("assignment target is not a column", func(t *testing.T) {
+		_, err := Parse(`UPDATE t SET a.f(x) = 1 WHERE b`)
+		assert.Error(t, err)
+
+		query, err := Parse(`UPDATE t SET t.s.field = 1`)
+		assert.NoError(t, err)
+		assert.Equal(t, ColumnRef{Path: []string{"t", "s", "field"}}, query.Update.Assignments[0].Column)
+		assert.False(t, Update{Table: Table{Name: "t"}, Assignments: []Assignment{{Value: Literal{Kind: LiteralNumber, Val: "1"}}}}.Valid())
+	})
+
+	t.Run("qualified table", func(t *testing.T) {
+		query, err := Parse("UPDATE `ds.users` SET active = FALSE")
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "ds.users", Path: []string{"ds", "users"}}, query.Update.Table)
+	})
+
+	t.Run("target is not a table name", func(t *testing.T) {
+		for _, input := range []string{
+			`UPDATE (SELECT 1) SET a = 1`,
+			`UPDATE UNNEST(x) SET a = 1`,
+		} {
+			_, err := Parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestParse_Delete(t *testing.T) {
+	t.Run("with where", func(t *testing.T) {
+		query, err := Parse(`DELETE FROM users u WHERE u.last_login < '2020-01-01';`)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementDelete, query.Stmt)
+		assert.Equal(t, &Delete{
+			Table: Table{Name: "users", Alias: "u"},
+			Where: Binary{Op: "<", Left: ColumnRef{Table: "u", Column: "last_login"}, Right: Literal{Kind: LiteralString, Val: "'2020-01-01'"}},
+		}, query.Delete)
+	})
+
+	t.Run("without from", func(t *testing.T) {
+		query, err := Parse(`DELETE users WHERE id IN (SELECT user_id FROM bans)`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "users"}, query.Delete.Table)
+		assert.IsType(t, In{}, query.Delete.Where)
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		query, err := Parse(`DELETE FROM users`)
+		assert.NoError(t, err)
+		assert.Nil(t, query.Delete.Where)
+	})
+
+	t.Run("missing table", func(t *testing.T) {
+		_, err := Parse(`DELETE FROM WHERE id = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("target is not a table name", func(t *testing.T) {
+		for _, input := range []string{
+			`DELETE FROM UNNEST(x) WHERE a = 1`,
+			`DELETE FROM (SELECT 1) WHERE a = 1`,
+		} {
+			_, err := Parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+
+	t.Run("unsupported statement", func(t *testing.T) {
+		_, err := Parse(`TRUNCATE users`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Merge(t *testing.T) {
+	t.Run("upsert", func(t *testing.T) {
+		input := `MERGE INTO inventory t
+			USING (SELECT product, SUM(quantity) AS quantity FROM shipments GROUP BY product) s
+			ON t.product = s.product
+			WHEN MATCHED AND s.quantity = 0 THEN DELETE
+			WHEN MATCHED THEN UPDATE SET quantity = t.quantity + s.quantity
+			WHEN NOT MATCHED THEN INSERT (product, quantity) VALUES (s.product, s.quantity)
+			WHEN NOT MATCHED BY SOURCE THEN UPDATE SET quantity = 0;`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, StatementMerge, query.Stmt)
+		merge := query.Merge
+		assert.Equal(t, Table{Name: "inventory", Alias: "t"}, merge.Target)
+		assert.Equal(t, "s", merge.Source.Alias)
+		assert.Len(t, merge.Source.Subquery.GroupBy, 1)
+		assert.Equal(t, Binary{Op: "=", Left: ColumnRef{Table: "t", Column: "product"}, Right: ColumnRef{Table: "s", Column: "product"}}, merge.On)
+		assert.Equal(t, []MergeClause{
+			{
+				Match:     MergeMatched,
+				Condition: Binary{Op: "=", Left: ColumnRef{Table: "s", Column: "quantity"}, Right: Literal{Kind: LiteralNumber, Val: "0"}},
+				Action:    MergeDelete,
+			},
+			{
+				Match:  MergeMatched,
+				Action: MergeUpdate,
+				Assignments: []Assignment{
+					{Column: ColumnRef{Column: "quantity"}, Value: Binary{Op: "+", Left: ColumnRef{Table: "t", Column: "quantity"}, Right: ColumnRef{Table: "s", Column: "quantity"}}},
+				},
+			},
+			{
+				Match:   MergeNotMatchedByTarget,
+				Action:  MergeInsert,
+				Columns: []string{"product", "quantity"},
+				Values:  []Expr{ColumnRef{Table: "s", Column: "product"}, ColumnRef{Table: "s", Column: "quantity"}},
+			},
+			{
+				Match:       MergeNotMatchedBySource,
+				Action:      MergeUpdate,
+				Assignments: []Assignment{{Column: ColumnRef{Column: "quantity"}, Value: Literal{Kind: LiteralNumber, Val: "0"}}},
+			},
+		}, merge.Clauses)
+	})
+
+	t.Run("insert row", func(t *testing.T) {
+		query, err := Parse(`MERGE customers USING staging ON customers.id = staging.id WHEN NOT MATCHED BY TARGET THEN INSERT ROW`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "customers"}, query.Merge.Target)
+		assert.Equal(t, Table{Name: "staging"}, query.Merge.Source)
+		assert.Equal(t, []MergeClause{{Match: MergeNotMatchedByTarget, Action: MergeInsert}}, query.Merge.Clauses)
+	})
+
+	t.Run("missing when", func(t *testing.T) {
+		_, err := Parse(`MERGE t USING s ON t.id = s.id`)
+		assert.Error(t, err)
+	})
+
+	t.Run("insert when matched", func(t *testing.T) {
+		_, err := Parse(`MERGE t USING s ON t.id = s.id WHEN MATCHED THEN INSERT ROW`)
+		assert.Error(t, err)
+	})
+
+	t.Run("update when not matched", func(t *testing.T) {
+		_, err := Parse(`MERGE t USING s ON t.id = s.id WHEN NOT MATCHED THEN UPDATE SET a = 1`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing on", func(t *testing.T) {
+		_, err := Parse(`MERGE t USING s WHEN MATCHED THEN DELETE`)
+		assert.Error(t, err)
+	})
+
+	t.Run("target is not a table name", func(t *testing.T) {
+		for _, input := range []string{
+			`MERGE INTO (SELECT 1 AS a) s USING t ON s.a = t.a WHEN MATCHED THEN DELETE`,
+			`MERGE INTO UNNEST(x) s USING t ON s.a = t.a WHEN MATCHED THEN DELETE`,
+			`MERGE INTO p TABLESAMPLE SYSTEM (10 PERCENT) USING t ON p.a = t.a WHEN MATCHED THEN DELETE`,
+			`MERGE INTO p PIVOT (SUM(a) FOR b IN ('x')) USING t ON p.a = t.a WHEN MATCHED THEN DELETE`,
+		} {
+			_, err := Parse(input)
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestParse_WindowFunctions(t *testing.T) {
+	t.Run("inline window", func(t *testing.T) {
+		input := `SELECT ROW_NUMBER() OVER (PARTITION BY dept, team ORDER BY salary DESC) AS rank FROM employees`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, Column{
+			Expr: FuncCall{Name: "ROW_NUMBER", Over: &WindowSpec{
+				PartitionBy: []Expr{ColumnRef{Column: "dept"}, ColumnRef{Column: "team"}},
+				OrderBy:     []OrderItem{{Expr: ColumnRef{Column: "salary"}, Direction: SortDesc}},
+			}},
+			Alias: "rank",
+		}, query.Selects[0])
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		query, err := Parse(`SELECT COUNT(*) OVER () FROM employees`)
+		assert.NoError(t, err)
+		assert.Equal(t, FuncCall{Name: "COUNT", Args: []Expr{Star{}}, Over: &WindowSpec{}}, query.Selects[0].Expr)
+	})
+
+	t.Run("frames", func(t *testing.T) {
+		input := `SELECT
+			SUM(x) OVER (ORDER BY d ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW),
+			AVG(x) OVER (ORDER BY d RANGE BETWEEN 2 PRECEDING AND 1 + 1 FOLLOWING),
+			MAX(x) OVER (ORDER BY d GROUPS 3 PRECEDING)
+		FROM t`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects, 3)
+		assert.Equal(t, &Frame{
+			Unit:  FrameRows,
+			Start: FrameBound{Kind: FrameUnboundedPreceding},
+			End:   &FrameBound{Kind: FrameCurrentRow},
+		}, query.Selects[0].Expr.(FuncCall).Over.Frame)
+		assert.Equal(t, &Frame{
+			Unit:  FrameRange,
+			Start: FrameBound{Kind: FramePreceding, Offset: Literal{Kind: LiteralNumber, Val: "2"}},
+			End: &FrameBound{Kind: FrameFollowing, Offset: Binary{
+				Op: "+", Left: Literal{Kind: LiteralNumber, Val: "1"}, Right: Literal{Kind: LiteralNumber, Val: "1"},
+			}},
+		}, query.Selects[1].Expr.(FuncCall).Over.Frame)
+		assert.Equal(t, &Frame{
+			Unit:  FrameGroups,
+			Start: FrameBound{Kind: FramePreceding, Offset: Literal{Kind: LiteralNumber, Val: "3"}},
+		}, query.Selects[2].Expr.(FuncCall).Over.Frame)
+	})
+
+	t.Run("named windows", func(t *testing.T) {
+		input := `SELECT SUM(x) OVER w, AVG(x) OVER (w ORDER BY d) FROM t
+			WHERE x > 0
+			WINDOW w AS (PARTITION BY k), w2 AS (w ROWS UNBOUNDED PRECEDING)
+			ORDER BY k`
+		query, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, &WindowSpec{Name: "w"}, query.Selects[0].Expr.(FuncCall).Over)
+		assert.Equal(t, &WindowSpec{Name: "w", OrderBy: []OrderItem{{Expr: ColumnRef{Column: "d"}}}}, query.Selects[1].Expr.(FuncCall).Over)
+		assert.Equal(t, []NamedWindow{
+			{Name: "w", Spec: WindowSpec{PartitionBy: []Expr{ColumnRef{Column: "k"}}}},
+			{Name: "w2", Spec: WindowSpec{Name: "w", Frame: &Frame{Unit: FrameRows, Start: FrameBound{Kind: FrameUnboundedPreceding}}}},
+		}, query.Window)
+		assert.Len(t, query.OrderBy, 1)
+	})
+
+	t.Run("invalid frame start", func(t *testing.T) {
+		_, err := Parse(`SELECT SUM(x) OVER (ROWS BETWEEN UNBOUNDED FOLLOWING AND CURRENT ROW) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing frame direction", func(t *testing.T) {
+		_, err := Parse(`SELECT SUM(x) OVER (ROWS 3) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("window without as", func(t *testing.T) {
+		_, err := Parse(`SELECT SUM(x) OVER w FROM t WINDOW w (PARTITION BY k)`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_FunctionCalls(t *testing.T) {
+	t.Run("aggregate modifiers", func(t *testing.T) {
+		query, err := Parse(`SELECT ARRAY_AGG(DISTINCT x IGNORE NULLS ORDER BY y DESC LIMIT 10) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, FuncCall{
+			Name:     "ARRAY_AGG",
+			Distinct: true,
+			Args:     []Expr{ColumnRef{Column: "x"}},
+			Nulls:    IgnoreNulls,
+			OrderBy:  []OrderItem{{Expr: ColumnRef{Column: "y"}, Direction: SortDesc}},
+			Limit:    Literal{Kind: LiteralNumber, Val: "10"},
+		}, query.Selects[0].Expr)
+	})
+
+	t.Run("respect nulls", func(t *testing.T) {
+		query, err := Parse(`SELECT LAST_VALUE(x RESPECT NULLS) OVER (ORDER BY d) FROM t`)
+		assert.NoError(t, err)
+		call := query.Selects[0].Expr.(FuncCall)
+		assert.Equal(t, RespectNulls, call.Nulls)
+		assert.NotNil(t, call.Over)
+	})
+
+	t.Run("count distinct", func(t *testing.T) {
+		query, err := Parse(`SELECT COUNT(DISTINCT user_id) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, FuncCall{Name: "COUNT", Distinct: true, Args: []Expr{ColumnRef{Column: "user_id"}}}, query.Selects[0].Expr)
+	})
+
+	t.Run("qualified name", func(t *testing.T) {
+		query, err := Parse(`SELECT SAFE.DIVIDE(a, b) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, FuncCall{Name: "SAFE.DIVIDE", Args: []Expr{ColumnRef{Column: "a"}, ColumnRef{Column: "b"}}}, query.Selects[0].Expr)
+	})
+
+	t.Run("keyword names", func(t *testing.T) {
+		query, err := Parse(`SELECT IF(a > 0, a, 0), LEFT(name, 3) FROM t`)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects, 2)
+		assert.Equal(t, "IF", query.Selects[0].Expr.(FuncCall).Name)
+		assert.Len(t, query.Selects[0].Expr.(FuncCall).Args, 3)
+		assert.Equal(t, "LEFT", query.Selects[1].Expr.(FuncCall).Name)
+	})
+
+	t.Run("named arguments", func(t *testing.T) {
+		query, err := Parse(`SELECT ST_GEOGFROMTEXT(wkt, make_valid => TRUE) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, FuncCall{Name: "ST_GEOGFROMTEXT", Args: []Expr{
+			ColumnRef{Column: "wkt"},
+			NamedArg{Name: "make_valid", Value: Literal{Kind: LiteralBool, Val: "TRUE"}},
+		}}, query.Selects[0].Expr)
+	})
+
+	t.Run("ignore without nulls", func(t *testing.T) {
+		_, err := Parse(`SELECT ARRAY_AGG(x IGNORE) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unclosed call", func(t *testing.T) {
+		_, err := Parse(`SELECT ARRAY_AGG(x ORDER BY y FROM t`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_SpecialExpressions(t *testing.T) {
+	t.Run("searched case", func(t *testing.T) {
+		query, err := Parse(`SELECT CASE WHEN score >= 90 THEN 'A' WHEN score >= 80 THEN 'B' ELSE 'C' END AS grade FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, Column{
+			Expr: Case{
+				Whens: []When{
+					{Cond: Binary{Op: ">=", Left: ColumnRef{Column: "score"}, Right: Literal{Kind: LiteralNumber, Val: "90"}}, Result: Literal{Kind: LiteralString, Val: "'A'"}},
+					{Cond: Binary{Op: ">=", Left: ColumnRef{Column: "score"}, Right: Literal{Kind: LiteralNumber, Val: "80"}}, Result: Literal{Kind: LiteralString, Val: "'B'"}},
+				},
+				Else: Literal{Kind: LiteralString, Val: "'C'"},
+			},
+			Alias: "grade",
+		}, query.Selects[0])
+	})
+
+	t.Run("simple case", func(t *testing.T) {
+		query, err := Parse(`SELECT CASE status WHEN 1 THEN 'on' END FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, Case{
+			Operand: ColumnRef{Column: "status"},
+			Whens:   []When{{Cond: Literal{Kind: LiteralNumber, Val: "1"}, Result: Literal{Kind: LiteralString, Val: "'on'"}}},
+		}, query.Selects[0].Expr)
+	})
+
+	t.Run("cast", func(t *testing.T) {
+		query, err := Parse(`SELECT CAST(id AS STRING(10)), SAFE_CAST(tags AS ARRAY<INT64>), CAST(x AS ARRAY<STRUCT<a INT64, b STRING>>) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, Cast{
+			Expr: ColumnRef{Column: "id"},
+			Type: types.Type{Base: types.ColumnTypeString, Size: "10"},
+		}, query.Selects[0].Expr)
+		assert.Equal(t, Cast{
+			Expr: ColumnRef{Column: "tags"},
+			Type: types.Type{Base: types.ColumnTypeArray, Elem: &types.Type{Base: types.ColumnTypeInt64}},
+			Safe: true,
+		}, query.Selects[1].Expr)
+		assert.Equal(t, "ARRAY<STRUCT<a INT64, b STRING>>", query.Selects[2].Expr.(Cast).Type.String())
+	})
+
+	t.Run("extract", func(t *testing.T) {
+		query, err := Parse(`SELECT EXTRACT(YEAR FROM created_at AT TIME ZONE 'UTC'), EXTRACT(week(monday) FROM d) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, Extract{
+			Part:     "YEAR",
+			Expr:     ColumnRef{Column: "created_at"},
+			TimeZone: Literal{Kind: LiteralString, Val: "'UTC'"},
+		}, query.Selects[0].Expr)
+		assert.Equal(t, "WEEK(MONDAY)", query.Selects[1].Expr.(Extract).Part)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		query, err := Parse(`SELECT DATE_ADD(d, INTERVAL 5 DAY), INTERVAL '1-2' YEAR TO MONTH FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, Interval{Expr: Literal{Kind: LiteralNumber, Val: "5"}, Unit: "DAY"}, query.Selects[0].Expr.(FuncCall).Args[1])
+		assert.Equal(t, Interval{Expr: Literal{Kind: LiteralString, Val: "'1-2'"}, Unit: "YEAR", To: "MONTH"}, query.Selects[1].Expr)
+	})
+
+	t.Run("case without when", func(t *testing.T) {
+		_, err := Parse(`SELECT CASE ELSE 1 END FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("case without end", func(t *testing.T) {
+		_, err := Parse(`SELECT CASE WHEN a THEN 1 FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("cast without type", func(t *testing.T) {
+		_, err := Parse(`SELECT CAST(x AS) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unclosed array type", func(t *testing.T) {
+		_, err := Parse(`SELECT CAST(x AS ARRAY<INT64) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("types are not limited to the known column types", func(t *testing.T) {
+		for _, input := range []string{
+			`SELECT CAST(x AS DATETIME) FROM t`,
+			`SELECT SAFE_CAST(x AS BIGNUMERIC) FROM t`,
+			`SELECT CAST(x AS ARRAY<STRUCT<a GEOGRAPHY>>) FROM t`,
+			`SELECT ARRAY<TIME>[x] FROM t`,
+			`SELECT STRUCT<a INTERVAL>(x) FROM t`,
+		} {
+			_, err := Parse(input)
+			assert.NoError(t, err, input)
+		}
+
+		query, err := Parse(`SELECT CAST(x AS DATETIME) FROM t`)
+		assert.NoError(t, err)
+		assert.False(t, query.Selects[0].Expr.(Cast).Type.Known())
+	})
+}
+
+func TestParse_StarModifiers(t *testing.T) {
+	t.Run("except", func(t *testing.T) {
+		query, err := Parse(`SELECT * EXCEPT (password, salt) FROM users`)
+		assert.NoError(t, err)
+		assert.Equal(t, Column{Expr: Star{Except: []string{"password", "salt"}}}, query.Selects[0])
+		assert.Equal(t, ColumnAsterisk, query.Selects[0].Column())
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		query, err := Parse(`SELECT u.* EXCEPT (salt) REPLACE (UPPER(name) AS name, 0 score), o.id FROM users u JOIN orders o USING (id)`)
+		assert.NoError(t, err)
+		assert.Len(t, query.Selects, 2)
+		assert.Equal(t, Star{
+			Table:  "u",
+			Except: []string{"salt"},
+			Replace: []Column{
+				{Expr: FuncCall{Name: "UPPER", Args: []Expr{ColumnRef{Column: "name"}}}, Alias: "name"},
+				{Expr: Literal{Kind: LiteralNumber, Val: "0"}, Alias: "score"},
+			},
+		}, query.Selects[0].Expr)
+	})
+
+	t.Run("set operation after star", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM a EXCEPT DISTINCT SELECT * FROM b`)
+		assert.NoError(t, err)
+		assert.NotNil(t, query.Compound)
+	})
+
+	t.Run("replace without name", func(t *testing.T) {
+		_, err := Parse(`SELECT * REPLACE (UPPER(name)) FROM users`)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty except", func(t *testing.T) {
+		_, err := Parse(`SELECT * EXCEPT () FROM users`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_SelectModifiers(t *testing.T) {
+	t.Run("distinct", func(t *testing.T) {
+		query, err := Parse(`SELECT DISTINCT country FROM users`)
+		assert.NoError(t, err)
+		assert.True(t, query.Distinct)
+		assert.Equal(t, []Column{{Expr: ColumnRef{Column: "country"}}}, query.Selects)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		query, err := Parse(`SELECT ALL country FROM users`)
+		assert.NoError(t, err)
+		assert.False(t, query.Distinct)
+		assert.Len(t, query.Selects, 1)
+	})
+
+	t.Run("as struct", func(t *testing.T) {
+		query, err := Parse(`SELECT (SELECT DISTINCT AS STRUCT id, name FROM users LIMIT 1) FROM t`)
+		assert.NoError(t, err)
+		sub := query.Selects[0].Expr.(Subquery).Query
+		assert.True(t, sub.Distinct)
+		assert.Equal(t, SelectAsStruct, sub.SelectAs)
+		assert.Len(t, sub.Selects, 2)
+	})
+
+	t.Run("as value", func(t *testing.T) {
+		query, err := Parse(`SELECT AS VALUE STRUCT_VALUE FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, SelectAsValue, query.SelectAs)
+		assert.False(t, query.Distinct)
+	})
+
+	t.Run("set operation operands", func(t *testing.T) {
+		query, err := Parse(`SELECT DISTINCT AS STRUCT a FROM x UNION ALL SELECT AS VALUE b FROM y`)
+		assert.NoError(t, err)
+		assert.False(t, query.Distinct)
+		assert.Empty(t, query.SelectAs)
+		assert.True(t, query.Compound.Queries[0].Distinct)
+		assert.Equal(t, SelectAsStruct, query.Compound.Queries[0].SelectAs)
+		assert.Equal(t, SelectAsValue, query.Compound.Queries[1].SelectAs)
+	})
+
+	t.Run("invalid select as", func(t *testing.T) {
+		_, err := Parse(`SELECT AS TABLE a FROM t`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Arrays(t *testing.T) {
+	t.Run("unnest", func(t *testing.T) {
+		query, err := Parse(`SELECT item, pos FROM t, UNNEST(t.items) AS item WITH OFFSET AS pos`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{
+			{Name: "t"},
+			{Unnest: ColumnRef{Table: "t", Column: "items"}, Alias: "item", WithOffset: true, OffsetAlias: "pos"},
+		}, query.Froms)
+	})
+
+	t.Run("unnest join", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM t CROSS JOIN UNNEST(tags) tag WITH OFFSET WHERE tag = 'a'`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Unnest: ColumnRef{Column: "tags"}, Alias: "tag", WithOffset: true}, query.Froms[0].Joins[0].Table)
+		assert.NotNil(t, query.Where)
+	})
+
+	t.Run("subscripts", func(t *testing.T) {
+		query, err := Parse(`SELECT arr[OFFSET(1)], arr[safe_ordinal(2)], arr[0], items[OFFSET(0)].name FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Column{
+			{Expr: Index{Expr: ColumnRef{Column: "arr"}, Kind: IndexOffset, Index: Literal{Kind: LiteralNumber, Val: "1"}}},
+			{Expr: Index{Expr: ColumnRef{Column: "arr"}, Kind: IndexSafeOrdinal, Index: Literal{Kind: LiteralNumber, Val: "2"}}},
+			{Expr: Index{Expr: ColumnRef{Column: "arr"}, Index: Literal{Kind: LiteralNumber, Val: "0"}}},
+			{Expr: FieldAccess{
+				Expr:  Index{Expr: ColumnRef{Column: "items"}, Kind: IndexOffset, Index: Literal{Kind: LiteralNumber, Val: "0"}},
+				Field: "name",
+			}},
+		}, query.Selects)
+	})
+
+	t.Run("field paths", func(t *testing.T) {
+		query, err := Parse(`SELECT a.b.c, a.b.c.d, (a).b FROM t WHERE a.b.order > 1`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Column{
+			{Expr: ColumnRef{Path: []string{"a", "b", "c"}}},
+			{Expr: ColumnRef{Path: []string{"a", "b", "c", "d"}}},
+			{Expr: FieldAccess{Expr: Paren{Expr: ColumnRef{Column: "a"}}, Field: "b"}},
+		}, query.Selects)
+		assert.Equal(t, ColumnRef{Path: []string{"a", "b", "order"}}, query.Where.(Binary).Left)
+	})
+
+	t.Run("unclosed subscript", func(t *testing.T) {
+		_, err := Parse(`SELECT arr[OFFSET(1) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unnest without parentheses", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM UNNEST arr`)
+		assert.Error(t, err)
+	})
+
+	t.Run("offset alias without name", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM UNNEST(arr) WITH OFFSET AS`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_QualifiedNames(t *testing.T) {
+	t.Run("dotted table path", func(t *testing.T) {
+		query, err := Parse(`SELECT e.id FROM myproject.analytics.events e`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{
+			Name:  "myproject.analytics.events",
+			Path:  []string{"myproject", "analytics", "events"},
+			Alias: "e",
+		}}, query.Froms)
+	})
+
+	t.Run("backticked table path", func(t *testing.T) {
+		for _, input := range []string{
+			"SELECT * FROM `my-project.dataset.table` AS t",
+			"SELECT * FROM `my-project`.dataset.`table` AS t",
+			"SELECT * FROM `my-project.dataset`.table AS t",
+		} {
+			query, err := Parse(input)
+			assert.NoError(t, err)
+			assert.Equal(t, []Table{{
+				Name:  "my-project.dataset.table",
+				Path:  []string{"my-project", "dataset", "table"},
+				Alias: "t",
+			}}, query.Froms, input)
+		}
+	})
+
+	t.Run("qualified join and insert", func(t *testing.T) {
+		query, err := Parse("SELECT * FROM a JOIN `ds.b` ON a.id = `ds.b`.id")
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "ds.b", Path: []string{"ds", "b"}}, query.Froms[0].Joins[0].Table)
+		assert.Equal(t, ColumnRef{Path: []string{"ds", "b", "id"}}, query.Froms[0].Joins[0].On.(Binary).Right)
+
+		query, err = Parse(`INSERT INTO ds.users (id) VALUES (1)`)
+		assert.NoError(t, err)
+		assert.Equal(t, Table{Name: "ds.users", Path: []string{"ds", "users"}}, query.Insert.Table)
+	})
+
+	t.Run("backticked aliases and lists", func(t *testing.T) {
+		query, err := Parse("SELECT `a` `b`, x.c AS `d` FROM `t` `x` JOIN u USING (`id`)")
+		assert.NoError(t, err)
+		assert.Equal(t, "b", query.Selects[0].Alias)
+		assert.Equal(t, "d", query.Selects[1].Alias)
+		assert.Equal(t, "x", query.Froms[0].Alias)
+		assert.Equal(t, query.Froms[0].Alias, query.Selects[1].Expr.(ColumnRef).Table)
+		assert.Equal(t, []string{"id"}, query.Froms[0].Joins[0].Using)
+
+		query, err = Parse("INSERT INTO t (`a`, b) VALUES (1, 2)")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, query.Insert.Columns)
+
+		query, err = Parse("SELECT * EXCEPT (`a`) FROM t")
+		assert.NoError(t, err)
+		assert.Equal(t, Star{Except: []string{"a"}}, query.Selects[0].Expr)
+	})
+
+	t.Run("backticked cte name", func(t *testing.T) {
+		query, err := Parse("WITH `c` (`id`) AS (SELECT 1) SELECT * FROM c")
+		assert.NoError(t, err)
+		assert.Equal(t, "c", query.With.CTEs[0].Name)
+		assert.Equal(t, []string{"id"}, query.With.CTEs[0].Columns)
+		assert.True(t, query.Froms[0].CTE)
+	})
+
+	t.Run("column parts", func(t *testing.T) {
+		query, err := Parse("SELECT `dataset.users`.name, dataset.users.*, dataset.users.name FROM dataset.users")
+		assert.NoError(t, err)
+		assert.Equal(t, ColumnRef{Path: []string{"dataset", "users", "name"}}, query.Selects[0].Expr)
+		assert.Equal(t, []string{"dataset", "users", "name"}, query.Selects[0].Expr.(ColumnRef).Parts())
+		assert.Empty(t, query.Selects[0].Table())
+		assert.Empty(t, query.Selects[0].Column())
+		assert.Equal(t, Star{Table: "dataset.users"}, query.Selects[1].Expr)
+		assert.Equal(t, query.Selects[0].Expr, query.Selects[2].Expr)
+		assert.Equal(t, []string{"name"}, ColumnRef{Column: "name"}.Parts())
+		assert.Equal(t, []string{"t", "name"}, ColumnRef{Table: "t", Column: "name"}.Parts())
+	})
+
+	t.Run("unqualified table", func(t *testing.T) {
+		query, err := Parse("SELECT * FROM `users`")
+		assert.NoError(t, err)
+		assert.Equal(t, []Table{{Name: "users"}}, query.Froms)
+	})
+
+	t.Run("missing name part", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM dataset.'users'`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_Literals(t *testing.T) {
+	t.Run("arrays", func(t *testing.T) {
+		query, err := Parse(`SELECT [1, 2, 3], ARRAY<INT64>[], ARRAY['a'][OFFSET(0)] FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, ArrayLiteral{Elems: []Expr{
+			Literal{Kind: LiteralNumber, Val: "1"}, Literal{Kind: LiteralNumber, Val: "2"}, Literal{Kind: LiteralNumber, Val: "3"},
+		}}, query.Selects[0].Expr)
+		assert.Equal(t, ArrayLiteral{Type: &types.Type{Base: types.ColumnTypeArray, Elem: &types.Type{Base: types.ColumnTypeInt64}}}, query.Selects[1].Expr)
+		assert.Equal(t, Index{
+			Expr:  ArrayLiteral{Elems: []Expr{Literal{Kind: LiteralString, Val: "'a'"}}},
+			Kind:  IndexOffset,
+			Index: Literal{Kind: LiteralNumber, Val: "0"},
+		}, query.Selects[2].Expr)
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		query, err := Parse(`SELECT STRUCT(1 AS a, 'x' AS b), STRUCT<id INT64, tags ARRAY<STRING>>(1, []) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, StructLiteral{Fields: []Column{
+			{Expr: Literal{Kind: LiteralNumber, Val: "1"}, Alias: "a"},
+			{Expr: Literal{Kind: LiteralString, Val: "'x'"}, Alias: "b"},
+		}}, query.Selects[0].Expr)
+		typed := query.Selects[1].Expr.(StructLiteral)
+		assert.Equal(t, "STRUCT<id INT64, tags ARRAY<STRING>>", typed.Type.String())
+		assert.Equal(t, []Column{{Expr: Literal{Kind: LiteralNumber, Val: "1"}}, {Expr: ArrayLiteral{}}}, typed.Fields)
+	})
+
+	t.Run("tuples", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM t WHERE (a, b) IN ((1, 'x'), (2, 'y'))`)
+		assert.NoError(t, err)
+		in := query.Where.(In)
+		assert.Equal(t, Tuple{Elems: []Expr{ColumnRef{Column: "a"}, ColumnRef{Column: "b"}}}, in.Expr)
+		assert.Equal(t, Tuple{Elems: []Expr{Literal{Kind: LiteralNumber, Val: "1"}, Literal{Kind: LiteralString, Val: "'x'"}}}, in.List[0])
+	})
+
+	t.Run("typed literals", func(t *testing.T) {
+		query, err := Parse(`SELECT DATE '2024-01-01', timestamp '2024-01-01 00:00:00+00', JSON '{"a": 1}', NUMERIC '1.23', DATE(ts) FROM t`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Column{
+			{Expr: TypedLiteral{Type: types.ColumnTypeDate, Val: "'2024-01-01'"}},
+			{Expr: TypedLiteral{Type: types.ColumnTypeTimestamp, Val: "'2024-01-01 00:00:00+00'"}},
+			{Expr: TypedLiteral{Type: types.ColumnTypeJSON, Val: `'{"a": 1}'`}},
+			{Expr: TypedLiteral{Type: types.ColumnTypeNumeric, Val: "'1.23'"}},
+			{Expr: FuncCall{Name: "DATE", Args: []Expr{ColumnRef{Column: "ts"}}}},
+		}, query.Selects)
+	})
+
+	t.Run("literal types", func(t *testing.T) {
+		assert.Equal(t, types.ColumnTypeInt64, Literal{Kind: LiteralNumber, Val: "42"}.Type())
+		assert.Equal(t, types.ColumnTypeInt64, Literal{Kind: LiteralNumber, Val: "0xE1"}.Type())
+		assert.Equal(t, types.ColumnTypeFloat64, Literal{Kind: LiteralNumber, Val: "1.5e3"}.Type())
+		assert.Equal(t, types.ColumnTypeString, Literal{Kind: LiteralString, Val: "'x'"}.Type())
+		assert.Equal(t, types.ColumnTypeBool, Literal{Kind: LiteralBool, Val: "TRUE"}.Type())
+		assert.Empty(t, Literal{Kind: LiteralNull, Val: "NULL"}.Type())
+	})
+
+	t.Run("unclosed array", func(t *testing.T) {
+		_, err := Parse(`SELECT [1, 2 FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("struct field without name", func(t *testing.T) {
+		_, err := Parse(`SELECT STRUCT(1 AS) FROM t`)
+		assert.Error(t, err)
+	})
+
+	t.Run("typed array without elements", func(t *testing.T) {
+		_, err := Parse(`SELECT ARRAY<INT64> FROM t`)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_TableOperators(t *testing.T) {
+	t.Run("pivot", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM sales PIVOT(SUM(amount) AS total, COUNT(*) n FOR quarter IN ('Q1' AS q1, 'Q2')) AS p WHERE q1_total > 0`)
+		assert.NoError(t, err)
+		assert.Equal(t, &Pivot{
+			Aggregates: []Column{
+				{Expr: FuncCall{Name: "SUM", Args: []Expr{ColumnRef{Column: "amount"}}}, Alias: "total"},
+				{Expr: FuncCall{Name: "COUNT", Args: []Expr{Star{}}}, Alias: "n"},
+			},
+			For: ColumnRef{Column: "quarter"},
+			In: []Column{
+				{Expr: Literal{Kind: LiteralString, Val: "'Q1'"}, Alias: "q1"},
+				{Expr: Literal{Kind: LiteralString, Val: "'Q2'"}},
+			},
+			Alias: "p",
+		}, query.Froms[0].Pivot)
+		assert.Equal(t, "sales", query.Froms[0].Name)
+		assert.NotNil(t, query.Where)
+	})
+
+	t.Run("unpivot", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM produce AS x UNPIVOT INCLUDE NULLS (sales FOR quarter IN (q1 AS 'Q1', q2 AS 'Q2'))`)
+		assert.NoError(t, err)
+		assert.Equal(t, "x", query.Froms[0].Alias)
+		assert.Equal(t, &Unpivot{
+			IncludeNulls: true,
+			Values:       []string{"sales"},
+			Name:         "quarter",
+			In: []Column{
+				{Expr: ColumnRef{Column: "q1"}, Alias: "'Q1'"},
+				{Expr: ColumnRef{Column: "q2"}, Alias: "'Q2'"},
+			},
+		}, query.Froms[0].Unpivot)
+	})
+
+	t.Run("multi column unpivot", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM produce UNPIVOT EXCLUDE NULLS ((first_half, second_half) FOR semesters IN ((q1, q2) AS 'H1', (q3, q4) AS 'H2')) u`)
+		assert.NoError(t, err)
+		unpivot := query.Froms[0].Unpivot
+		assert.False(t, unpivot.IncludeNulls)
+		assert.Equal(t, []string{"first_half", "second_half"}, unpivot.Values)
+		assert.Equal(t, Tuple{Elems: []Expr{ColumnRef{Column: "q1"}, ColumnRef{Column: "q2"}}}, unpivot.In[0].Expr)
+		assert.Equal(t, "u", unpivot.Alias)
+	})
+
+	t.Run("tablesample", func(t *testing.T) {
+		query, err := Parse(`SELECT * FROM dataset.events TABLESAMPLE SYSTEM (10 PERCENT) e JOIN users TABLESAMPLE reservoir (100 ROWS) ON e.uid = users.id`)
+		assert.NoError(t, err)
+		assert.Equal(t, &TableSample{Method: "SYSTEM", Size: Literal{Kind: LiteralNumber, Val: "10"}, Unit: SamplePercent}, query.Froms[0].Sample)
+		assert.Equal(t, "e", query.Froms[0].Alias)
+		assert.Equal(t, &TableSample{Method: "RESERVOIR", Size: Literal{Kind: LiteralNumber, Val: "100"}, Unit: SampleRows}, query.Froms[0].Joins[0].Table.Sample)
+	})
+
+	t.Run("pivot without for", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM sales PIVOT(SUM(amount) IN ('Q1'))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unpivot without values", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM produce UNPIVOT (FOR quarter IN (q1))`)
+		assert.Error(t, err)
+	})
+
+	t.Run("tablesample without unit", func(t *testing.T) {
+		_, err := Parse(`SELECT * FROM events TABLESAMPLE SYSTEM (10)`)
+		assert.Error(t, err)
+	})
+}
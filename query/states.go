@@ -16,88 +16,736 @@ func sqlStatement(p *parse.Parser[Query]) parse.StateFn[Query] {
 		switch strings.ToUpper(next.Val) {
 		case StatementSelect.String():
 			p.Result.Stmt = StatementSelect
-			return sqlColumns
+			return sqlSelect
+		case strings.ToUpper(lex.KeywordWith):
+			return sqlWith
+		case strings.ToUpper(lex.KeywordMerge):
+			p.Result.Stmt = StatementMerge
+			return sqlMerge
 		default:
 			return p.Errorf("unsupported keyword found [%s]", next.Val)
 		}
+	case lex.ItemIdentifier: // data modifying statements are not reserved keywords
+		switch strings.ToUpper(next.Val) {
+		case StatementInsert.String():
+			p.Result.Stmt = StatementInsert
+			return sqlInsert
+		case StatementUpdate.String():
+			p.Result.Stmt = StatementUpdate
+			return sqlUpdate
+		case StatementDelete.String():
+			p.Result.Stmt = StatementDelete
+			return sqlDelete
+		default:
+			return p.Errorf("unsupported statement found [%s]", next.Val)
+		}
+	case lex.ItemLeftParen: // a parenthesized query, the first operand of a set operation
+		p.Backup()
+		return sqlParenQuery
 	default:
 		return p.Errorf("unsupported next type [%v] found within [%s]", next.Typ, "sqlStatement")
 	}
 }
 
+// sqlParenQuery parses a parenthesized query used as an operand of a set operation
+func sqlParenQuery(p *parse.Parser[Query]) parse.StateFn[Query] {
+	query, ok := parenQuery(p)
+	if !ok {
+		return p.Errorf("expected a query within parentheses, found [%s] instead", p.MustPeek().Val)
+	}
+	p.Result.Stmt = StatementSelect
+	p.Result.operands = append(p.Result.operands, setOperand{query: query})
+	return sqlSetOperation
+}
+
+func sqlWith(p *parse.Parser[Query]) parse.StateFn[Query] {
+	p.Result.With = &With{}
+	if lex.IsKeyword(p.MustPeek(), lex.KeywordRecursive) {
+		p.Result.With.Recursive = true
+		p.Skip()
+	}
+	return sqlCTE
+}
+
+func sqlCTE(p *parse.Parser[Query]) parse.StateFn[Query] {
+	var cte CTE
+	switch name := p.MustNext(); name.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		cte.Name = identifier(name)
+	default:
+		return p.Errorf("expected common table expression name, found [%s] instead", name.Val)
+	}
+
+	if p.MustPeek().Typ == lex.ItemLeftParen {
+		cte.Columns = identifierList(p)
+	}
+	if !expectKeyword(p, lex.KeywordAs) {
+		return nil
+	}
+
+	// a recursive CTE is in scope within its own query
+	with := p.Result.With
+	if with.Recursive {
+		with.CTEs = append(with.CTEs, cte)
+	}
+	query, ok := parenQuery(p)
+	if with.Recursive {
+		with.CTEs = with.CTEs[:len(with.CTEs)-1]
+	}
+	if !ok {
+		return p.Errorf("expected subquery for common table expression [%s]", cte.Name)
+	}
+	cte.Query = query
+
+	if p.MustPeek().Typ == lex.ItemComma {
+		p.Skip()
+		return addCTE(p, cte, sqlCTE)
+	}
+	return addCTE(p, cte, sqlWithBody)
+}
+
+// sqlWithBody parses the query following the WITH clause
+func sqlWithBody(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if next := p.MustPeek(); !lex.IsKeyword(next, lex.KeywordSelect) && next.Typ != lex.ItemLeftParen {
+		return p.Errorf("expected SELECT after the WITH clause, found [%s] instead", next.Val)
+	}
+	return sqlStatement
+}
+
+// sqlSelect parses the modifiers that may follow SELECT: ALL or DISTINCT, then AS STRUCT or AS VALUE
+func sqlSelect(p *parse.Parser[Query]) parse.StateFn[Query] {
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordDistinct):
+		p.Result.Distinct = true
+	case lex.IsKeyword(next, lex.KeywordAll):
+	default:
+		p.Backup()
+	}
+
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordAs) {
+		return sqlColumns
+	}
+	p.Skip()
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordStruct):
+		p.Result.SelectAs = SelectAsStruct
+	case lex.IsIdentifier(next, "value"):
+		p.Result.SelectAs = SelectAsValue
+	default:
+		return p.Errorf("expected STRUCT or VALUE after SELECT AS, found [%s] instead", next.Val)
+	}
+	return sqlColumns
+}
+
 func sqlColumns(p *parse.Parser[Query]) parse.StateFn[Query] {
-	var col Column
+	col := Column{Expr: selectExpr(p)}
+	if p.HasError() {
+		return nil
+	}
+
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordAs): // AS means the next identifier is an alias
+		switch alias := p.MustNext(); alias.Typ {
+		case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+			col.Alias = identifier(alias)
+		default:
+			return p.Errorf("expected identifier, found [%v]", alias.Typ)
+		}
+	case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier: // AS is optional, assume this is the alias
+		col.Alias = identifier(next)
+	default:
+		p.Backup()
+	}
+
+	switch next := p.MustNext(); {
+	case next.Typ == lex.ItemComma: // a ',' indicates the end of a select statement item
+		return addSelect(p, col, sqlColumns)
+	case lex.IsKeyword(next, lex.KeywordFrom):
+		return addSelect(p, col, sqlFrom)
+	default: // a SELECT without a FROM clause
+		p.Backup()
+		return addSelect(p, col, sqlWhere)
+	}
+}
+
+func sqlFrom(p *parse.Parser[Query]) parse.StateFn[Query] {
+	tbl := tableRef(p)
+	if p.HasError() {
+		return nil
+	}
+	return addFrom(p, tbl, sqlFromNext)
+}
+
+// sqlFromNext looks for another table or a join following a table in the FROM clause
+func sqlFromNext(p *parse.Parser[Query]) parse.StateFn[Query] {
+	switch next := p.MustPeek(); {
+	case next.Typ == lex.ItemComma: // look for more tables in the FROM clause
+		p.Skip()
+		return sqlFrom
+	case lex.IsKeyword(next, lex.KeywordJoin, lex.KeywordInner, lex.KeywordOuter, lex.KeywordLeft, lex.KeywordRight, lex.KeywordFull, lex.KeywordCross, lex.KeywordNatural):
+		return sqlJoin
+	default: // anything else ends the FROM clause
+		return sqlWhere
+	}
+}
+
+func sqlJoin(p *parse.Parser[Query]) parse.StateFn[Query] {
+	var join Join
+	next := p.MustNext()
+	if lex.IsKeyword(next, lex.KeywordNatural) {
+		join.Natural = true
+		next = p.MustNext()
+	}
+
+	switch {
+	case lex.IsKeyword(next, lex.KeywordInner):
+		join.Kind = JoinInner
+		next = p.MustNext()
+	case lex.IsKeyword(next, lex.KeywordCross):
+		join.Kind = JoinCross
+		next = p.MustNext()
+	case lex.IsKeyword(next, lex.KeywordLeft, lex.KeywordRight, lex.KeywordFull):
+		join.Kind = JoinKind(strings.ToUpper(next.Val))
+		if next = p.MustNext(); lex.IsKeyword(next, lex.KeywordOuter) { // OUTER is optional
+			next = p.MustNext()
+		}
+	case lex.IsKeyword(next, lex.KeywordOuter):
+		return p.Errorf("expected LEFT, RIGHT or FULL before OUTER")
+	default:
+		join.Kind = JoinInner
+	}
+
+	if !lex.IsKeyword(next, lex.KeywordJoin) {
+		return p.Errorf("expected JOIN, found [%s] instead", next.Val)
+	}
+
+	join.Table = tableRef(p)
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordOn):
+		join.On = expression(p)
+	case lex.IsKeyword(next, lex.KeywordUsing):
+		join.Using = identifierList(p)
+	default:
+		p.Backup()
+	}
+	if p.HasError() {
+		return nil
+	}
+
+	return addJoin(p, join, sqlFromNext)
+}
+
+// tableRef parses a table name or a parenthesized subquery, and its optional alias
+func tableRef(p *parse.Parser[Query]) Table {
+	var tbl Table
+	if query, ok := parenQuery(p); ok {
+		tbl.Subquery = query
+	} else if lex.IsKeyword(p.MustPeek(), lex.KeywordUnnest) {
+		p.Skip()
+		if !expectItem(p, lex.ItemLeftParen) {
+			return tbl
+		}
+		tbl.Unnest = expression(p)
+		if !expectItem(p, lex.ItemRightParen) {
+			return tbl
+		}
+	}
+
 	for {
 		switch next := p.MustNext(); {
-		case next.Typ == lex.ItemComma: // a ',' indicates the end of a select statement item
-			return addSelect(p, col, sqlColumns)
-		case isKeyword(next, lex.KeywordFrom):
-			return addSelect(p, col, sqlFrom)
-		case isKeyword(next, lex.KeywordAs): // AS means the next identifier is an alias
+		case lex.IsKeyword(next, lex.KeywordTablesample):
+			if tbl.Sample = tableSample(p); tbl.Sample == nil {
+				return tbl
+			}
+			continue
+		case lex.IsIdentifier(next, "pivot") && p.MustPeek().Typ == lex.ItemLeftParen:
+			if tbl.Pivot = pivot(p); tbl.Pivot == nil {
+				return tbl
+			}
+			continue
+		case lex.IsIdentifier(next, "unpivot") && (p.MustPeek().Typ == lex.ItemLeftParen || lex.IsIdentifier(p.MustPeek(), "include") || lex.IsKeyword(p.MustPeek(), "exclude")):
+			if tbl.Unpivot = unpivot(p); tbl.Unpivot == nil {
+				return tbl
+			}
+			continue
+		case lex.IsKeyword(next, lex.KeywordWith) && tbl.Unnest != nil && lex.IsIdentifier(p.MustPeek(), "offset"):
+			p.Skip()
+			tbl.WithOffset = true
+			switch alias := p.MustNext(); {
+			case lex.IsKeyword(alias, lex.KeywordAs):
+				if alias = p.MustNext(); alias.Typ != lex.ItemIdentifier && alias.Typ != lex.ItemBacktickedIdentifier {
+					p.Errorf("expected identifier, found [%v]", alias.Typ)
+					return tbl
+				}
+				tbl.OffsetAlias = identifier(alias)
+			case alias.Typ == lex.ItemIdentifier, alias.Typ == lex.ItemBacktickedIdentifier:
+				tbl.OffsetAlias = identifier(alias)
+			default:
+				p.Backup()
+			}
+			continue
+		case lex.IsKeyword(next, lex.KeywordAs): // AS means the next identifier is an alias
 			switch alias := p.MustNext(); alias.Typ {
-			case lex.ItemIdentifier:
-				col.Alias = alias.Val
+			case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+				tbl.Alias = identifier(alias)
 				continue
 			default:
-				return p.Errorf("expected identifier, found [%v]", alias.Typ)
+				p.Errorf("expected identifier, found [%v]", alias.Typ)
+				return tbl
 			}
 		case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier:
-			switch peek := p.MustPeek(); peek.Typ {
-			case lex.ItemDot: // '.' indicates this is a table/column combo
-				col.Table = next.Val
-				p.Skip()
-				continue
-			case lex.ItemIdentifier: // AS is optional, assume this is the alias
-				col.Column = next.Val
-				col.Alias = peek.Val
-				p.Skip()
+			switch {
+			case tbl.Alias != "": // both name and alias are set, something is wrong
+				p.Errorf("unknown identifier in [%s], tbl name and alias are already set [%s %s]", "tableRef", tbl.Name, tbl.Alias)
+				return tbl
+			case tbl.Name != "", tbl.Subquery != nil, tbl.Unnest != nil: // the table is already set, this is its alias
+				tbl.Alias = identifier(next)
 				continue
 			default:
-				col.Column += next.Val
+				if !setTableName(p, &tbl, next) {
+					return tbl
+				}
 				continue
 			}
-		case next.Val == ColumnAsterisk: // '*' indicates a wildcard
-			col.Column = ColumnAsterisk
-			continue
-		default:
-			return p.Errorf("unsupported next type [%v] found within [%s]", next.Typ, "sqlColumns")
+		default: // anything else ends the table reference
+			p.Backup()
+			tbl.CTE = tbl.Name != "" && isCTE(p, tbl.Name)
+			return tbl
 		}
 	}
 }
 
-func sqlFrom(p *parse.Parser[Query]) parse.StateFn[Query] {
-	var tbl Table
-	for {
+// pivot parses the body and alias of a PIVOT operator, PIVOT has already been read
+func pivot(p *parse.Parser[Query]) *Pivot {
+	p.Skip() // skip the '('
+	var pv Pivot
+	pv.Aggregates = aliasedExprs(p)
+	if !expectKeyword(p, lex.KeywordFor) {
+		return nil
+	}
+	switch next := p.MustNext(); next.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		pv.For = exprColumnRef(p, next)
+	default:
+		p.Errorf("expected pivot column after FOR, found [%s] instead", next.Val)
+		return nil
+	}
+	if !expectKeyword(p, lex.KeywordIn) || !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	pv.In = aliasedExprs(p)
+	if !expectItem(p, lex.ItemRightParen) || !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+
+	alias, ok := optionalAlias(p)
+	if !ok {
+		return nil
+	}
+	pv.Alias = alias
+
+	if !parse.Validate(&pv) {
+		p.Errorf("invalid PIVOT found")
+		return nil
+	}
+	return &pv
+}
+
+// unpivot parses the body and alias of an UNPIVOT operator, UNPIVOT has already been read
+func unpivot(p *parse.Parser[Query]) *Unpivot {
+	var up Unpivot
+	if nulls := p.MustNext(); lex.IsIdentifier(nulls, "include") || lex.IsKeyword(nulls, "exclude") {
+		up.IncludeNulls = lex.IsIdentifier(nulls, "include")
+		if !expectKeyword(p, lex.KeywordNulls) {
+			return nil
+		}
+	} else {
+		p.Backup()
+	}
+
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	switch next := p.MustNext(); next.Typ {
+	case lex.ItemLeftParen: // a tuple of value columns
+		p.Backup()
+		up.Values = identifierList(p)
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		up.Values = []string{identifier(next)}
+	default:
+		p.Errorf("expected values column within UNPIVOT, found [%s] instead", next.Val)
+		return nil
+	}
+	if !expectKeyword(p, lex.KeywordFor) {
+		return nil
+	}
+	switch next := p.MustNext(); next.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		up.Name = identifier(next)
+	default:
+		p.Errorf("expected name column after FOR, found [%s] instead", next.Val)
+		return nil
+	}
+	if !expectKeyword(p, lex.KeywordIn) || !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	up.In = aliasedExprs(p)
+	if !expectItem(p, lex.ItemRightParen) || !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+
+	alias, ok := optionalAlias(p)
+	if !ok {
+		return nil
+	}
+	up.Alias = alias
+
+	if !parse.Validate(&up) {
+		p.Errorf("invalid UNPIVOT found")
+		return nil
+	}
+	return &up
+}
+
+// tableSample parses the method and size of a TABLESAMPLE clause, TABLESAMPLE has already been read
+func tableSample(p *parse.Parser[Query]) *TableSample {
+	var sample TableSample
+	switch method := p.MustNext(); method.Typ {
+	case lex.ItemIdentifier:
+		sample.Method = strings.ToUpper(method.Val)
+	default:
+		p.Errorf("expected sampling method after TABLESAMPLE, found [%s] instead", method.Val)
+		return nil
+	}
+
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+	sample.Size = expression(p)
+	switch unit := p.MustNext(); {
+	case lex.IsIdentifier(unit, SamplePercent.String()):
+		sample.Unit = SamplePercent
+	case lex.IsKeyword(unit, lex.KeywordRows):
+		sample.Unit = SampleRows
+	default:
+		p.Errorf("expected PERCENT or ROWS within TABLESAMPLE, found [%s] instead", unit.Val)
+		return nil
+	}
+	if !expectItem(p, lex.ItemRightParen) {
+		return nil
+	}
+	return &sample
+}
+
+// aliasedExprs parses a comma separated list of expressions, each with an optional alias. An
+// alias given with AS may also be a literal, as in UNPIVOT's `IN (q1 AS 'Q1')`.
+func aliasedExprs(p *parse.Parser[Query]) []Column {
+	var list []Column
+	for !p.HasError() {
+		col := Column{Expr: expression(p)}
 		switch next := p.MustNext(); {
-		case next.Typ == lex.ItemComma: // store the current table and look for more in the FROM clause
-			return addFrom(p, tbl, sqlFrom)
-		case isKeyword(next, lex.KeywordWhere):
-			return sqlWhere
-		case isKeyword(next, lex.KeywordJoin, lex.KeywordInner, lex.KeywordOuter, lex.KeywordLeft, lex.KeywordRight, lex.KeywordFull):
-			return sqlJoin
-		case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier:
-			switch {
-			case tbl.Alias != "": // both name and alias are set, something is wrong
-				return p.Errorf("unknown identifier in [%s], tbl name and alias are already set [%s %s]", "sqlFrom", tbl.Name, tbl.Alias)
-			case tbl.Name != "": // name is already set, this is the table alias
-				tbl.Alias = next.Val
-				continue
+		case lex.IsKeyword(next, lex.KeywordAs):
+			switch alias := p.MustNext(); alias.Typ {
+			case lex.ItemIdentifier, lex.ItemBacktickedIdentifier, lex.ItemString, lex.ItemNumber:
+				col.Alias = identifier(alias)
 			default:
-				tbl.Name = next.Val
-				continue
+				p.Errorf("expected alias, found [%s] instead", alias.Val)
+				return nil
 			}
-		case next.Typ == lex.ItemEOF, next.Typ == lex.ItemStatementEnd: // end of SQL select statement
-			return addFrom(p, tbl, nil)
+		case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier:
+			col.Alias = identifier(next)
+		default:
+			p.Backup()
+		}
+		list = append(list, col)
+
+		if p.MustPeek().Typ != lex.ItemComma {
+			return list
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// optionalAlias reads an `[AS] alias` when one follows, ok is false when AS is not followed by one
+func optionalAlias(p *parse.Parser[Query]) (alias string, ok bool) {
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordAs):
+		alias := p.MustNext()
+		if alias.Typ == lex.ItemIdentifier || alias.Typ == lex.ItemBacktickedIdentifier {
+			return identifier(alias), true
+		}
+		p.Errorf("expected identifier after AS, found [%s] instead", alias.Val)
+		return "", false
+	case next.Typ == lex.ItemIdentifier, next.Typ == lex.ItemBacktickedIdentifier:
+		return identifier(next), true
+	default:
+		p.Backup()
+		return "", true
+	}
+}
+
+// setTableName reads the possibly qualified name of a table, the first identifier has already
+// been read
+func setTableName(p *parse.Parser[Query], tbl *Table, first lex.Item) bool {
+	parts := namePath(p, first)
+	if parts == nil {
+		return false
+	}
+
+	tbl.Name = strings.Join(parts, ".")
+	if len(parts) > 1 {
+		tbl.Path = parts
+	}
+	return true
+}
+
+// identifierList parses a parenthesized, comma separated list of identifiers
+func identifierList(p *parse.Parser[Query]) []string {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+
+	var list []string
+	for {
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+			list = append(list, identifier(next))
 		default:
-			return p.Errorf("unsupported next type [%v] found within [%s]", next.Typ, "sqlFrom")
+			p.Errorf("expected identifier within list, found [%s] instead", next.Val)
+			return nil
+		}
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return list
+		default:
+			p.Errorf("expected ',' or ')' within list, found [%s] instead", next.Val)
+			return nil
 		}
 	}
 }
 
+// The optional clauses following the FROM clause are parsed by a chain of states in the order
+// they must appear. Each state moves on to the next clause when its own keyword is not found,
+// the state machine ends at the first item that does not start a clause.
+
 func sqlWhere(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordWhere) {
+		return sqlGroupBy
+	}
+	p.Skip()
+
+	p.Result.Where = expression(p)
+	return sqlGroupBy
+}
+
+func sqlGroupBy(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordGroup) {
+		return sqlHaving
+	}
+	p.Skip()
+
+	if !expectKeyword(p, lex.KeywordBy) {
+		return nil
+	}
+	for {
+		p.Result.GroupBy = append(p.Result.GroupBy, grouping(p))
+		if p.HasError() || p.MustPeek().Typ != lex.ItemComma {
+			return sqlHaving
+		}
+		p.Skip()
+	}
+}
+
+func sqlHaving(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordHaving) {
+		return sqlWindow
+	}
+	p.Skip()
+
+	p.Result.Having = expression(p)
+	return sqlWindow
+}
+
+func sqlWindow(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordWindow) {
+		return sqlSetOperation
+	}
+	p.Skip()
+
+	return sqlNamedWindow
+}
+
+// sqlNamedWindow parses a `name AS (spec)` item of the WINDOW clause
+func sqlNamedWindow(p *parse.Parser[Query]) parse.StateFn[Query] {
+	var window NamedWindow
+	switch name := p.MustNext(); name.Typ {
+	case lex.ItemIdentifier, lex.ItemBacktickedIdentifier:
+		window.Name = identifier(name)
+	default:
+		return p.Errorf("expected window name, found [%s] instead", name.Val)
+	}
+	if !expectKeyword(p, lex.KeywordAs) {
+		return nil
+	}
+	window.Spec = windowSpec(p)
+	if p.HasError() {
+		return nil
+	}
+
+	if p.MustPeek().Typ == lex.ItemComma {
+		p.Skip()
+		return addWindow(p, window, sqlNamedWindow)
+	}
+	return addWindow(p, window, sqlSetOperation)
+}
+
+// sqlSetOperation looks for a set operator following a SELECT, or a parenthesized query. The
+// operands are collected as they are parsed and combined once the last one has been read, so
+// that the ORDER BY and LIMIT that follow apply to the combined result.
+func sqlSetOperation(p *parse.Parser[Query]) parse.StateFn[Query] {
+	next := p.MustPeek()
+	if !lex.IsKeyword(next, lex.KeywordUnion, lex.KeywordIntersect, lex.KeywordExcept) {
+		if p.Result.operands != nil {
+			endSetOperation(p)
+		}
+		return sqlOrderBy
+	}
+	p.Skip()
+
+	operand := setOperand{op: SetOperator(strings.ToUpper(next.Val))}
+	switch modifier := p.MustNext(); {
+	case lex.IsKeyword(modifier, lex.KeywordAll):
+		operand.all = true
+	case lex.IsKeyword(modifier, lex.KeywordDistinct):
+	default:
+		p.Backup()
+	}
+
+	addSetOperand(p)
+	if query, ok := parenQuery(p); ok {
+		operand.query = query
+		p.Result.operands = append(p.Result.operands, operand)
+		return sqlSetOperation
+	}
+
+	// the SELECT that follows is parsed into p.Result and added to this operand by addSetOperand
+	p.Result.operands = append(p.Result.operands, operand)
+	if !expectKeyword(p, lex.KeywordSelect) {
+		return nil
+	}
+	return sqlSelect
+}
+
+func sqlOrderBy(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordOrder) {
+		return sqlLimit
+	}
+	p.Skip()
+
+	if !expectKeyword(p, lex.KeywordBy) {
+		return nil
+	}
+	p.Result.OrderBy = orderItems(p)
+	return sqlLimit
+}
+
+func sqlLimit(p *parse.Parser[Query]) parse.StateFn[Query] {
+	if !lex.IsKeyword(p.MustPeek(), lex.KeywordLimit) {
+		return nil
+	}
+	p.Skip()
+
+	p.Result.Limit = expression(p)
+	if lex.IsIdentifier(p.MustPeek(), "offset") {
+		p.Skip()
+		p.Result.Offset = expression(p)
+	}
 	return nil
 }
 
-func sqlJoin(p *parse.Parser[Query]) parse.StateFn[Query] {
+// grouping parses a single item of the GROUP BY clause
+func grouping(p *parse.Parser[Query]) Grouping {
+	switch next := p.MustNext(); {
+	case lex.IsKeyword(next, lex.KeywordRollup, lex.KeywordCube):
+		return Grouping{Kind: GroupingKind(strings.ToUpper(next.Val)), Items: groupingList(p)}
+	case lex.IsKeyword(next, lex.KeywordGrouping):
+		if sets := p.MustNext(); !lex.IsIdentifier(sets, "sets") {
+			p.Errorf("expected SETS after GROUPING, found [%s] instead", sets.Val)
+			return Grouping{}
+		}
+		return Grouping{Kind: GroupingSets, Items: groupingList(p)}
+	default:
+		p.Backup()
+		return Grouping{Kind: GroupingExpr, Expr: expression(p)}
+	}
+}
+
+// groupingList parses the parenthesized arguments of ROLLUP, CUBE and GROUPING SETS. An argument
+// is read as an expression, so `(a + b) * 2` stays a single expression, and only the empty () or
+// a parenthesized list of two or more expressions becomes a grouping set.
+func groupingList(p *parse.Parser[Query]) []Grouping {
+	if !expectItem(p, lex.ItemLeftParen) {
+		return nil
+	}
+
+	var items []Grouping
+	for !p.HasError() {
+		if p.MustPeek().Typ == lex.ItemLeftParen && p.MustPeekN(2).Typ == lex.ItemRightParen {
+			p.Skip()
+			p.Skip()
+			items = append(items, Grouping{Kind: GroupingSet})
+		} else {
+			item := grouping(p)
+			if tuple, ok := item.Expr.(Tuple); ok && item.Kind == GroupingExpr {
+				item = Grouping{Kind: GroupingSet}
+				for _, expr := range tuple.Elems {
+					item.Items = append(item.Items, Grouping{Kind: GroupingExpr, Expr: expr})
+				}
+			}
+			items = append(items, item)
+		}
+
+		switch next := p.MustNext(); next.Typ {
+		case lex.ItemComma:
+			continue
+		case lex.ItemRightParen:
+			return items
+		default:
+			p.Errorf("expected ',' or ')' within grouping list, found [%s] instead", next.Val)
+		}
+	}
+	return nil
+}
+
+// orderItems parses the comma separated items of an ORDER BY clause
+func orderItems(p *parse.Parser[Query]) []OrderItem {
+	var items []OrderItem
+	for !p.HasError() {
+		item := OrderItem{Expr: expression(p)}
+		if peek := p.MustPeek(); lex.IsKeyword(peek, lex.KeywordAsc, lex.KeywordDesc) {
+			item.Direction = SortDirection(strings.ToUpper(peek.Val))
+			p.Skip()
+		}
+		if lex.IsKeyword(p.MustPeek(), lex.KeywordNulls) {
+			p.Skip()
+			switch next := p.MustNext(); {
+			case lex.IsIdentifier(next, "first", "last"):
+				item.Nulls = NullsOrder(strings.ToUpper(next.Val))
+			default:
+				p.Errorf("expected FIRST or LAST after NULLS, found [%s] instead", next.Val)
+				return nil
+			}
+		}
+		items = append(items, item)
+
+		if p.MustPeek().Typ != lex.ItemComma {
+			return items
+		}
+		p.Skip()
+	}
 	return nil
 }
@@ -8,6 +8,10 @@ func (s Statement) String() string {
 
 const (
 	StatementSelect Statement = "SELECT"
+	StatementInsert Statement = "INSERT"
+	StatementUpdate Statement = "UPDATE"
+	StatementDelete Statement = "DELETE"
+	StatementMerge  Statement = "MERGE"
 )
 
 const (
@@ -17,25 +21,409 @@ const (
 type Query struct {
 	Comments []string
 	Stmt     Statement
+	With     *With
+	Distinct bool
+	SelectAs SelectAs
 	Selects  []Column
 	Froms    []Table
+	Where    Expr
+	GroupBy  []Grouping
+	Having   Expr
+	Window   []NamedWindow
+	OrderBy  []OrderItem
+	Limit    Expr
+	Offset   Expr
+	Compound *Compound
+	Insert   *Insert
+	Update   *Update
+	Delete   *Delete
+	Merge    *Merge
+
+	operands []setOperand // the operands of a set operation while it is being parsed
+}
+
+// SelectAs is the GoogleSQL `SELECT AS STRUCT` or `SELECT AS VALUE` form, which returns each row
+// as a single value rather than as columns
+type SelectAs string
+
+func (a SelectAs) String() string {
+	return string(a)
+}
+
+const (
+	SelectAsStruct SelectAs = "STRUCT"
+	SelectAsValue  SelectAs = "VALUE"
+)
+
+type SetOperator string
+
+func (o SetOperator) String() string {
+	return string(o)
+}
+
+const (
+	SetUnion     SetOperator = "UNION"
+	SetIntersect SetOperator = "INTERSECT"
+	SetExcept    SetOperator = "EXCEPT"
+)
+
+// Compound combines the results of two or more queries with a set operator. A query holding a
+// Compound has no SELECT list of its own, its ORDER BY, LIMIT and OFFSET apply to the combined
+// result. All is false for DISTINCT, which is also the default when neither is given.
+type Compound struct {
+	Op      SetOperator
+	All     bool
+	Queries []*Query
+}
+
+// setOperand is a query and the set operator preceding it, the first operand has no operator
+type setOperand struct {
+	op    SetOperator
+	all   bool
+	query *Query
+}
+
+// Insert is an INSERT statement. The inserted rows are either listed by Rows, one list of
+// values per VALUES row, or produced by Query.
+type Insert struct {
+	Table   Table
+	Columns []string
+	Rows    [][]Expr
+	Query   *Query
+}
+
+func (i Insert) Valid() bool {
+	if !i.Table.Valid() || (i.Rows != nil) == (i.Query != nil) {
+		return false
+	}
+	for _, row := range i.Rows {
+		if len(row) != len(i.Rows[0]) || (i.Columns != nil && len(row) != len(i.Columns)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Update is an UPDATE statement, Where is nil when every row of the table is updated
+type Update struct {
+	Table       Table
+	Assignments []Assignment
+	Where       Expr
+}
+
+func (u Update) Valid() bool {
+	for _, assignment := range u.Assignments {
+		if assignment.Column.Column == "" && assignment.Column.Path == nil {
+			return false
+		}
+	}
+	return u.Table.Valid() && len(u.Assignments) > 0
+}
+
+// Assignment is a `column = value` item of the SET clause, the value may be Default
+type Assignment struct {
+	Column ColumnRef
+	Value  Expr
+}
+
+// Delete is a DELETE statement, Where is nil when every row of the table is deleted
+type Delete struct {
+	Table Table
+	Where Expr
 }
 
+func (d Delete) Valid() bool {
+	return d.Table.Valid()
+}
+
+// Merge is a MERGE statement, its WHEN clauses are kept in the order they were written
+type Merge struct {
+	Target  Table
+	Source  Table
+	On      Expr
+	Clauses []MergeClause
+}
+
+func (m Merge) Valid() bool {
+	return m.Target.Valid() && m.Source.Valid() && m.On != nil && len(m.Clauses) > 0
+}
+
+type MergeMatch string
+
+func (m MergeMatch) String() string {
+	return string(m)
+}
+
+const (
+	MergeMatched            MergeMatch = "MATCHED"
+	MergeNotMatchedByTarget MergeMatch = "NOT MATCHED BY TARGET"
+	MergeNotMatchedBySource MergeMatch = "NOT MATCHED BY SOURCE"
+)
+
+type MergeAction string
+
+func (a MergeAction) String() string {
+	return string(a)
+}
+
+const (
+	MergeUpdate MergeAction = "UPDATE"
+	MergeInsert MergeAction = "INSERT"
+	MergeDelete MergeAction = "DELETE"
+)
+
+// MergeClause is a `WHEN [NOT] MATCHED [BY SOURCE|TARGET] [AND condition] THEN action` clause of
+// a MERGE. Assignments are set for UPDATE, Columns and Values for INSERT, where Values is nil
+// for INSERT ROW.
+type MergeClause struct {
+	Match       MergeMatch
+	Condition   Expr
+	Action      MergeAction
+	Assignments []Assignment
+	Columns     []string
+	Values      []Expr
+}
+
+func (c MergeClause) Valid() bool {
+	switch c.Action {
+	case MergeUpdate:
+		return c.Match != MergeNotMatchedByTarget && len(c.Assignments) > 0
+	case MergeDelete:
+		return c.Match != MergeNotMatchedByTarget
+	case MergeInsert:
+		return c.Match == MergeNotMatchedByTarget && (c.Columns == nil || len(c.Columns) == len(c.Values))
+	default:
+		return false
+	}
+}
+
+// With is the WITH clause of a query
+type With struct {
+	Recursive bool
+	CTEs      []CTE
+}
+
+// CTE is a common table expression defined within a WITH clause
+type CTE struct {
+	Name    string
+	Columns []string
+	Query   *Query
+}
+
+func (c CTE) Valid() bool {
+	return c.Name != "" && c.Query != nil
+}
+
+// Column is an item of the SELECT list
 type Column struct {
-	Table  string
-	Column string
-	Alias  string
+	Expr  Expr
+	Alias string
+}
+
+// Table returns the table qualifying a column reference or a star, it is empty for any other
+// expression and for a reference of more than two parts, see ColumnRef
+func (c Column) Table() string {
+	switch expr := c.Expr.(type) {
+	case ColumnRef:
+		return expr.Table
+	case Star:
+		return expr.Table
+	default:
+		return ""
+	}
+}
+
+// Column returns the name of a referenced column or ColumnAsterisk for a star, it is empty for any
+// other expression and for a reference of more than two parts, see ColumnRef
+func (c Column) Column() string {
+	switch expr := c.Expr.(type) {
+	case ColumnRef:
+		return expr.Column
+	case Star:
+		return ColumnAsterisk
+	default:
+		return ""
+	}
 }
 
 func (c Column) Valid() bool {
-	return c.Column != ""
+	return c.Expr != nil
 }
 
+// Table is a table source within the FROM clause, either a named table, a subquery or the
+// elements of an array with UNNEST. Name is the full name of a named table, Path holds its parts
+// when it is qualified, e.g. [my-project dataset events] for `my-project.dataset.events`. CTE is
+// set when the name refers to a common table expression rather than a base table. WithOffset and
+// OffsetAlias record the `WITH OFFSET [AS] alias` of an UNNEST, which adds a column holding each
+// element's position. Joins holds the tables joined onto a FROM item in the order they appear, so
+// `FROM a JOIN b ON ..., c` is the table a joined with b, followed by the table c.
 type Table struct {
-	Name  string
-	Alias string
+	Name        string
+	Path        []string
+	Alias       string
+	Subquery    *Query
+	Unnest      Expr
+	WithOffset  bool
+	OffsetAlias string
+	Pivot       *Pivot
+	Unpivot     *Unpivot
+	Sample      *TableSample
+	CTE         bool
+	Joins       []Join
 }
 
 func (t Table) Valid() bool {
-	return t.Name != ""
+	sources := 0
+	for _, set := range []bool{t.Name != "", t.Subquery != nil, t.Unnest != nil} {
+		if set {
+			sources++
+		}
+	}
+	if t.OffsetAlias != "" && !t.WithOffset {
+		return false
+	}
+	return sources == 1 && (t.Unnest != nil || !t.WithOffset)
+}
+
+// Pivot is a `PIVOT(aggregate FOR column IN (values))` operator applied to a table source, it
+// aggregates the rows for each of the values of For into a column of its own. The alias of a
+// value names its column.
+type Pivot struct {
+	Aggregates []Column
+	For        Expr
+	In         []Column
+	Alias      string
+}
+
+func (p Pivot) Valid() bool {
+	return len(p.Aggregates) > 0 && p.For != nil && len(p.In) > 0
+}
+
+// Unpivot is an `UNPIVOT(values FOR name IN (columns))` operator applied to a table source, it
+// turns the columns of In into rows. Values holds more than one column when each of In is a
+// tuple of columns, and IncludeNulls is set by INCLUDE NULLS.
+type Unpivot struct {
+	IncludeNulls bool
+	Values       []string
+	Name         string
+	In           []Column
+	Alias        string
+}
+
+func (u Unpivot) Valid() bool {
+	return len(u.Values) > 0 && u.Name != "" && len(u.In) > 0
+}
+
+type SampleUnit string
+
+func (u SampleUnit) String() string {
+	return string(u)
+}
+
+const (
+	SamplePercent SampleUnit = "PERCENT"
+	SampleRows    SampleUnit = "ROWS"
+)
+
+// TableSample is a `TABLESAMPLE method (size unit)` clause reading a sample of a table source,
+// e.g. TABLESAMPLE SYSTEM (10 PERCENT)
+type TableSample struct {
+	Method string
+	Size   Expr
+	Unit   SampleUnit
+}
+
+type JoinKind string
+
+func (k JoinKind) String() string {
+	return string(k)
+}
+
+const (
+	JoinInner JoinKind = "INNER"
+	JoinLeft  JoinKind = "LEFT"
+	JoinRight JoinKind = "RIGHT"
+	JoinFull  JoinKind = "FULL"
+	JoinCross JoinKind = "CROSS"
+)
+
+// Join is a table joined to the FROM clause. LEFT, RIGHT and FULL joins are always outer joins.
+type Join struct {
+	Kind    JoinKind
+	Natural bool
+	Table   Table
+	On      Expr
+	Using   []string
+}
+
+func (j Join) Valid() bool {
+	switch {
+	case !j.Table.Valid(), j.On != nil && j.Using != nil:
+		return false
+	case j.Kind == JoinCross, j.Natural: // the join condition is implied
+		return j.On == nil && j.Using == nil
+	default:
+		return j.On != nil || j.Using != nil
+	}
+}
+
+type GroupingKind string
+
+func (k GroupingKind) String() string {
+	return string(k)
+}
+
+const (
+	GroupingExpr   GroupingKind = "EXPR"
+	GroupingSet    GroupingKind = "SET" // a parenthesized list of expressions within ROLLUP, CUBE or GROUPING SETS
+	GroupingRollup GroupingKind = "ROLLUP"
+	GroupingCube   GroupingKind = "CUBE"
+	GroupingSets   GroupingKind = "GROUPING SETS"
+)
+
+// Grouping is an item of the GROUP BY clause. Expr is set for GroupingExpr, every other kind
+// holds its parenthesized arguments in Items.
+type Grouping struct {
+	Kind  GroupingKind
+	Expr  Expr
+	Items []Grouping
+}
+
+// NamedWindow is a window defined by the WINDOW clause, which OVER clauses may refer to by name
+type NamedWindow struct {
+	Name string
+	Spec WindowSpec
+}
+
+func (w NamedWindow) Valid() bool {
+	return w.Name != ""
+}
+
+type SortDirection string
+
+func (d SortDirection) String() string {
+	return string(d)
+}
+
+const (
+	SortAsc  SortDirection = "ASC"
+	SortDesc SortDirection = "DESC"
+)
+
+type NullsOrder string
+
+func (n NullsOrder) String() string {
+	return string(n)
+}
+
+const (
+	NullsFirst NullsOrder = "FIRST"
+	NullsLast  NullsOrder = "LAST"
+)
+
+// OrderItem is an item of an ORDER BY clause, Direction and Nulls are empty unless specified
+type OrderItem struct {
+	Expr      Expr
+	Direction SortDirection
+	Nulls     NullsOrder
 }
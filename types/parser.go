@@ -0,0 +1,128 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/ryan-holcombe/sqlparser/lex"
+	"github.com/ryan-holcombe/sqlparser/parse"
+)
+
+// typeParser reads a type from the items of p. GoogleSQL lexes `>>` as a single operator, so
+// closing tracks the `>` left over when one is found ending a nested type, e.g. ARRAY<STRUCT<a INT64>>.
+type typeParser[V any] struct {
+	p       *parse.Parser[V]
+	closing int
+}
+
+// Parse reads a data type from p, returning false when the type is invalid. The error is
+// reported through p.
+func Parse[V any](p *parse.Parser[V]) (Type, bool) {
+	tp := typeParser[V]{p: p}
+	typ := tp.dataType()
+	if tp.closing > 0 {
+		p.Errorf("unexpected [>] found after type [%s]", typ)
+	}
+	if !p.HasError() && !typ.Valid() {
+		p.Errorf("invalid type [%s] found", typ)
+	}
+	return typ, !p.HasError()
+}
+
+func (tp *typeParser[V]) dataType() Type {
+	p := tp.p
+	next := p.MustNext()
+	switch {
+	case lex.IsKeyword(next, lex.KeywordArray):
+		typ := Type{Base: ColumnTypeArray}
+		if !tp.openAngle() {
+			return typ
+		}
+		elem := tp.dataType()
+		typ.Elem = &elem
+		tp.closeAngle()
+		return typ
+	case lex.IsKeyword(next, lex.KeywordStruct):
+		typ := Type{Base: ColumnTypeStruct}
+		if !tp.openAngle() {
+			return typ
+		}
+		typ.Fields = tp.fields()
+		tp.closeAngle()
+		return typ
+	case next.Typ == lex.ItemIdentifier, lex.IsKeyword(next, lex.KeywordInterval): // INTERVAL is also reserved
+		typ := Type{Base: ColumnType(strings.ToUpper(next.Val))}
+		if p.MustPeek().Typ == lex.ItemLeftParen {
+			typ.Size = tp.size()
+		}
+		return typ
+	default:
+		p.Errorf("expected a type, found [%s] instead", next.Val)
+		return Type{}
+	}
+}
+
+// fields parses the comma separated fields of a STRUCT type
+func (tp *typeParser[V]) fields() []Field {
+	p := tp.p
+	var fields []Field
+	for !p.HasError() {
+		var field Field
+		if next, peek := p.MustNext(), p.MustPeek(); next.Typ == lex.ItemIdentifier &&
+			(peek.Typ == lex.ItemIdentifier || lex.IsKeyword(peek, lex.KeywordArray, lex.KeywordStruct, lex.KeywordInterval)) {
+			field.Name = next.Val
+		} else {
+			p.Backup()
+		}
+		field.Type = tp.dataType()
+		fields = append(fields, field)
+
+		if tp.closing > 0 || p.MustPeek().Typ != lex.ItemComma {
+			return fields
+		}
+		p.Skip()
+	}
+	return nil
+}
+
+// size parses the parameter of a sized type, e.g. the 10 of STRING(10) or MAX of BYTES(MAX)
+func (tp *typeParser[V]) size() string {
+	p := tp.p
+	p.Skip() // skip the '('
+	next := p.MustNext()
+	if next.Typ != lex.ItemNumber && next.Typ != lex.ItemIdentifier {
+		p.Errorf("expected type size, found [%s] instead", next.Val)
+		return ""
+	}
+	if end := p.MustNext(); end.Typ != lex.ItemRightParen {
+		p.Errorf("expected right parenthesis after type size, found [%s] instead", end.Val)
+		return ""
+	}
+	return strings.ToUpper(next.Val)
+}
+
+func (tp *typeParser[V]) openAngle() bool {
+	if next := tp.p.MustNext(); next.Typ != lex.ItemOperator || next.Val != "<" {
+		tp.p.Errorf("expected [<] after type, found [%s] instead", next.Val)
+		return false
+	}
+	return true
+}
+
+func (tp *typeParser[V]) closeAngle() bool {
+	if tp.closing > 0 {
+		tp.closing--
+		return true
+	}
+
+	next := tp.p.MustNext()
+	switch {
+	case next.Typ == lex.ItemOperator && next.Val == ">":
+		return true
+	case next.Typ == lex.ItemOperator && next.Val == ">>":
+		tp.closing++
+		return true
+	default:
+		tp.p.Errorf("expected [>] to close type, found [%s] instead", next.Val)
+		return false
+	}
+}
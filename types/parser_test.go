@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ryan-holcombe/sqlparser/parse"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("scalar types", func(t *testing.T) {
+		typ, ok := Parse(parse.NewParser[Type](`int64`))
+		assert.True(t, ok)
+		assert.Equal(t, Type{Base: ColumnTypeInt64}, typ)
+
+		typ, ok = Parse(parse.NewParser[Type](`STRING(MAX)`))
+		assert.True(t, ok)
+		assert.Equal(t, Type{Base: ColumnTypeString, Size: "MAX"}, typ)
+	})
+
+	t.Run("types other than the column types", func(t *testing.T) {
+		typ, ok := Parse(parse.NewParser[Type](`STRUCT<d DATETIME, i INTERVAL>`))
+		assert.True(t, ok)
+		assert.Equal(t, "STRUCT<d DATETIME, i INTERVAL>", typ.String())
+		assert.False(t, typ.Known())
+	})
+
+	t.Run("nested types", func(t *testing.T) {
+		typ, ok := Parse(parse.NewParser[Type](`ARRAY<STRUCT<id INT64, tags ARRAY<STRING(10)>>>`))
+		assert.True(t, ok)
+		assert.Equal(t, Type{Base: ColumnTypeArray, Elem: &Type{Base: ColumnTypeStruct, Fields: []Field{
+			{Name: "id", Type: Type{Base: ColumnTypeInt64}},
+			{Name: "tags", Type: Type{Base: ColumnTypeArray, Elem: &Type{Base: ColumnTypeString, Size: "10"}}},
+		}}}, typ)
+		assert.Equal(t, "ARRAY<STRUCT<id INT64, tags ARRAY<STRING(10)>>>", typ.String())
+	})
+
+	t.Run("anonymous struct fields", func(t *testing.T) {
+		typ, ok := Parse(parse.NewParser[Type](`STRUCT<INT64, STRUCT<x BOOL>>`))
+		assert.True(t, ok)
+		assert.Equal(t, "STRUCT<INT64, STRUCT<x BOOL>>", typ.String())
+	})
+
+	t.Run("array of arrays", func(t *testing.T) {
+		_, ok := Parse(parse.NewParser[Type](`ARRAY<ARRAY<INT64>>`))
+		assert.False(t, ok)
+	})
+
+	t.Run("extra close", func(t *testing.T) {
+		_, ok := Parse(parse.NewParser[Type](`STRUCT<a INT64>>`))
+		assert.False(t, ok)
+	})
+
+	t.Run("missing close", func(t *testing.T) {
+		_, ok := Parse(parse.NewParser[Type](`ARRAY<INT64`))
+		assert.False(t, ok)
+	})
+}
+
+func TestType_Known(t *testing.T) {
+	for input, known := range map[string]bool{
+		`INT64`:                              true,
+		`STRING(MAX)`:                        true,
+		`ARRAY<STRUCT<a BYTES(16), b DATE>>`: true,
+		`INT64(10)`:                          false,
+		`VARCHAR(MAX)`:                       false,
+		`ARRAY<STRUCT<a INT>>`:               false,
+	} {
+		typ, ok := Parse(parse.NewParser[Type](input))
+		assert.True(t, ok, input)
+		assert.Equal(t, known, typ.Known(), input)
+	}
+}
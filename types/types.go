@@ -0,0 +1,108 @@
+package types
+
+import (
+	"strings"
+)
+
+type ColumnType string
+
+func (s ColumnType) String() string {
+	return string(s)
+}
+
+const (
+	ColumnTypeBool      ColumnType = "BOOL"
+	ColumnTypeInt64     ColumnType = "INT64"
+	ColumnTypeFloat64   ColumnType = "FLOAT64"
+	ColumnTypeNumeric   ColumnType = "NUMERIC"
+	ColumnTypeString    ColumnType = "STRING"
+	ColumnTypeBytes     ColumnType = "BYTES"
+	ColumnTypeDate      ColumnType = "DATE"
+	ColumnTypeTimestamp ColumnType = "TIMESTAMP"
+	ColumnTypeJSON      ColumnType = "JSON"
+	ColumnTypeArray     ColumnType = "ARRAY"
+	ColumnTypeStruct    ColumnType = "STRUCT"
+)
+
+// Type is a data type such as INT64, STRING(10), ARRAY<INT64> or STRUCT<a INT64, b STRING>.
+// Size is the parameter of a sized type, Elem is set for ARRAY and Fields for STRUCT.
+type Type struct {
+	Base   ColumnType
+	Size   string
+	Elem   *Type
+	Fields []Field
+}
+
+// Field is a field of a STRUCT type, Name is empty for an anonymous field
+type Field struct {
+	Name string
+	Type Type
+}
+
+func (t Type) String() string {
+	var sb strings.Builder
+	sb.WriteString(t.Base.String())
+	if t.Size != "" {
+		sb.WriteString("(" + t.Size + ")")
+	}
+
+	switch t.Base {
+	case ColumnTypeArray:
+		if t.Elem != nil {
+			sb.WriteString("<" + t.Elem.String() + ">")
+		}
+	case ColumnTypeStruct:
+		sb.WriteString("<")
+		for i, f := range t.Fields {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if f.Name != "" {
+				sb.WriteString(f.Name + " ")
+			}
+			sb.WriteString(f.Type.String())
+		}
+		sb.WriteString(">")
+	}
+
+	return sb.String()
+}
+
+func (t Type) Valid() bool {
+	switch t.Base {
+	case "":
+		return false
+	case ColumnTypeArray:
+		return t.Elem != nil && t.Elem.Base != ColumnTypeArray && t.Size == ""
+	case ColumnTypeStruct:
+		return t.Size == ""
+	default:
+		return t.Elem == nil && len(t.Fields) == 0
+	}
+}
+
+// Known reports whether t is built only from the ColumnType constants, with a size given only to
+// the STRING and BYTES types that take one
+func (t Type) Known() bool {
+	if !t.Valid() {
+		return false
+	}
+
+	switch t.Base {
+	case ColumnTypeString, ColumnTypeBytes:
+		return true
+	case ColumnTypeBool, ColumnTypeInt64, ColumnTypeFloat64, ColumnTypeNumeric, ColumnTypeDate, ColumnTypeTimestamp, ColumnTypeJSON:
+		return t.Size == ""
+	case ColumnTypeArray:
+		return t.Elem.Known()
+	case ColumnTypeStruct:
+		for _, f := range t.Fields {
+			if !f.Type.Known() {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}